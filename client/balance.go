@@ -0,0 +1,97 @@
+package client
+
+import (
+	"fmt"
+	"sync"
+
+	"github.com/okex/okchain-go-sdk/types"
+	"github.com/okex/okchain-go-sdk/types/msg"
+)
+
+// reservations holds the funds of the txs in flight by the addresses of their senders, which the
+// available funds queried from the chain don't reflect yet. It's shared by the copies of a client.
+type reservations struct {
+	mtx    sync.Mutex
+	byAddr map[string]types.DecCoins
+}
+
+func newReservations() *reservations {
+	return &reservations{byAddr: make(map[string]types.DecCoins)}
+}
+
+// SetBalanceCheck sets whether the available funds of the sender are checked before Send and
+// NewOrders broadcast their txs. A tx which they don't cover, less the funds reserved for the
+// other txs in flight of the client, fails with a *types.InsufficientFundsError without being
+// broadcast. It's disabled by default.
+func (cli *OKChainClient) SetBalanceCheck(enabled bool) {
+	cli.balanceCheck = enabled
+}
+
+// ReservedFunds returns the funds of the address reserved for the txs in flight of the client
+func (cli *OKChainClient) ReservedFunds(addr string) types.DecCoins {
+	cli.reservations.mtx.Lock()
+	defer cli.reservations.mtx.Unlock()
+	return cli.reservations.byAddr[addr]
+}
+
+// RequiredFundsOfOrders returns the funds locked by the orders: the price times the quantity in
+// the quote asset for a buy order, and the quantity in the base asset for a sell order
+func (cli *OKChainClient) RequiredFundsOfOrders(orderItems []msg.OrderItem) (types.DecCoins, error) {
+	required := types.DecCoins{}
+	for _, item := range orderItems {
+		tokenPair, err := cli.GetProductInfo(item.Product)
+		if err != nil {
+			return nil, err
+		}
+		var funds types.DecCoin
+		if item.Side == "BUY" {
+			funds = types.DecCoin{Denom: tokenPair.QuoteAssetSymbol, Amount: item.Price.Mul(item.Quantity)}
+		} else {
+			funds = types.DecCoin{Denom: tokenPair.BaseAssetSymbol, Amount: item.Quantity}
+		}
+		required = required.Add(types.DecCoins{funds})
+	}
+	return required, nil
+}
+
+// reserveFunds checks that the available funds of the address cover the required funds and the
+// fee of the network besides the funds reserved already, and reserves them until the returned
+// release is called. Nothing is checked if the balance check is disabled.
+func (cli *OKChainClient) reserveFunds(addr string, required types.DecCoins) (release func(), err error) {
+	if !cli.balanceCheck {
+		return func() {}, nil
+	}
+	required = required.Add(cli.network.Fees.ToDecCoins())
+
+	cli.reservations.mtx.Lock()
+	defer cli.reservations.mtx.Unlock()
+
+	tokensInfo, err := cli.GetTokensInfoByAddr(addr)
+	if err != nil {
+		return nil, err
+	}
+	available := types.DecCoins{}
+	for _, coinInfo := range tokensInfo.Currencies {
+		amount, err := types.NewDecFromStr(coinInfo.Available)
+		if err != nil {
+			return nil, fmt.Errorf("invalid available amount %q of %s: %s", coinInfo.Available, coinInfo.Symbol, err)
+		}
+		available = available.Add(types.DecCoins{{Denom: coinInfo.Symbol, Amount: amount}})
+	}
+
+	reserved := cli.reservations.byAddr[addr]
+	if !available.IsAllGTE(reserved.Add(required)) {
+		return nil, &types.InsufficientFundsError{Address: addr, Required: required, Available: available, Reserved: reserved}
+	}
+	cli.reservations.byAddr[addr] = reserved.Add(required)
+
+	return func() {
+		cli.reservations.mtx.Lock()
+		defer cli.reservations.mtx.Unlock()
+		if remaining := cli.reservations.byAddr[addr].Sub(required); remaining.Empty() {
+			delete(cli.reservations.byAddr, addr)
+		} else {
+			cli.reservations.byAddr[addr] = remaining
+		}
+	}, nil
+}
@@ -0,0 +1,79 @@
+package client
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/okex/okchain-go-sdk/client/fakenode"
+	"github.com/okex/okchain-go-sdk/types"
+	"github.com/okex/okchain-go-sdk/utils"
+)
+
+func TestBalanceCheck(t *testing.T) {
+	node := fakenode.New()
+	defer node.Close()
+	fromInfo, _, err := utils.CreateAccountWithMnemo(mnemonic, name, passWd)
+	if err != nil {
+		t.Fatal(err)
+	}
+	coins, _ := utils.ParseCoins("20okt,100xxb")
+	node.AddAccount(fromInfo.GetAddress(), coins)
+	node.AddProduct(types.TokenPair{BaseAssetSymbol: "xxb", QuoteAssetSymbol: "okt", MaxPriceDigit: 4, MaxQuantityDigit: 4, MinQuantity: types.NewDecWithPrec(1, 4)})
+	cli := NewClient(node.Addr())
+	cli.SetBalanceCheck(true)
+	accInfo, err := cli.GetAccountInfoByAddr(addr)
+	if err != nil {
+		t.Fatal(err)
+	}
+	accNum, seq := accInfo.GetAccountNumber(), accInfo.GetSequence()
+	receiver := "okchain1hw4r48aww06ldrfeuq2v438ujnl6alszzzqpph"
+
+	// the funds reserved for a tx in flight aren't available to the others
+	release, err := cli.reserveFunds(addr, types.NewDecCoins(types.NewDecCoinFromDec("okt", types.NewDec(15))))
+	if err != nil {
+		t.Fatal(err)
+	}
+	height := node.Height()
+	_, err = cli.Send(fromInfo, passWd, receiver, "6okt", "", accNum, seq)
+	var fundsErr *types.InsufficientFundsError
+	if !errors.As(err, &fundsErr) || !types.IsErrInsufficientFunds(err) {
+		t.Fatalf("unexpected error of an uncovered transfer: %v", err)
+	}
+	if fundsErr.Reserved.String() != "15.00000000okt" || fundsErr.Shortfall().String() != "1.00000000okt" {
+		t.Fatalf("unexpected insufficient funds: %s", fundsErr)
+	}
+	if node.Height() != height {
+		t.Fatal("an uncovered transfer is broadcast")
+	}
+	release()
+	if reserved := cli.ReservedFunds(addr); !reserved.Empty() {
+		t.Fatalf("the funds are still reserved: %s", reserved)
+	}
+	if _, err := cli.Send(fromInfo, passWd, receiver, "6okt", "", accNum, seq); err != nil {
+		t.Fatal(err)
+	}
+	seq++
+
+	// a buy order needs the price times the quantity of the quote asset, a sell order the quantity
+	// of the base asset
+	if _, err := cli.NewOrder(fromInfo, passWd, "xxb_okt", "BUY", "4", "2", "", accNum, seq); err != nil {
+		t.Fatal(err)
+	}
+	seq++
+	if _, err := cli.NewOrder(fromInfo, passWd, "xxb_okt", "BUY", "7", "1", "", accNum, seq); !errors.As(err, &fundsErr) {
+		t.Fatalf("unexpected error of an uncovered buy order: %v", err)
+	}
+	if _, err := cli.NewOrder(fromInfo, passWd, "xxb_okt", "SELL", "4", "100", "", accNum, seq); err != nil {
+		t.Fatal(err)
+	}
+	seq++
+	if _, err := cli.NewOrder(fromInfo, passWd, "xxb_okt", "SELL", "4", "0.5", "", accNum, seq); !errors.As(err, &fundsErr) || fundsErr.Required.String() != "0.50000000xxb" {
+		t.Fatalf("unexpected error of an uncovered sell order: %v", err)
+	}
+
+	// nothing is checked by default, so the chain rejects the transfer
+	unchecked := NewClient(node.Addr())
+	if _, err := unchecked.Send(fromInfo, passWd, receiver, "100okt", "", accNum, seq); errors.As(err, &fundsErr) || !types.IsErrInsufficientFunds(err) {
+		t.Fatalf("unexpected error of an unchecked transfer: %v", err)
+	}
+}
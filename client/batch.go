@@ -0,0 +1,205 @@
+package client
+
+import (
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/okex/okchain-go-sdk/common"
+	"github.com/okex/okchain-go-sdk/crypto/keys"
+	"github.com/okex/okchain-go-sdk/types"
+	"github.com/okex/okchain-go-sdk/types/msg"
+)
+
+const (
+	// DefaultBatchCommitTimeout is how long a pipelined batch waits for each of its txs to be
+	// committed
+	DefaultBatchCommitTimeout = 30 * time.Second
+
+	batchPollInterval = 200 * time.Millisecond
+)
+
+// ErrBatchAborted is the error of the items of a batch which aren't broadcast, since a tx of an
+// earlier chunk failed and the sequences of the following txs aren't valid anymore
+var ErrBatchAborted = errors.New("the batch was aborted by a failed tx before the item was broadcast")
+
+// ErrOrderResultUnknown is the error of the new orders of a committed tx whose results aren't in
+// its response, so whether they're placed has to be queried
+var ErrOrderResultUnknown = errors.New("the result of the order isn't in the response of its tx")
+
+// BatchParams are the params of NewOrdersBatch and CancelOrdersBatch
+type BatchParams struct {
+	// ChunkSize is the number of items of a tx, which can't exceed common.OrderItemLimit. It's
+	// common.OrderItemLimit if it's 0.
+	ChunkSize int
+	// Pipelined broadcasts the txs in sync mode one after another without waiting for the blocks
+	// between them, and waits for them to be committed afterwards. Otherwise every tx is committed
+	// before the next one is broadcast. Only a tx rejected by CheckTx aborts a pipelined batch,
+	// since the following txs are broadcast before the earlier ones are delivered.
+	Pipelined bool
+	// CommitTimeout is how long a pipelined batch waits for each tx to be committed. It's
+	// DefaultBatchCommitTimeout if it's 0.
+	CommitTimeout time.Duration
+}
+
+// BatchItemResult is the result of an item of a batch. The results are in the order of the input
+// items.
+type BatchItemResult struct {
+	// Index is the index of the item in the input
+	Index int
+	// OrderID is the id of the new order, or the one of the canceled order
+	OrderID string
+	// TxHash and Height are the hash and the height of the tx of the item. The hash is empty if
+	// the item wasn't broadcast.
+	TxHash string
+	Height int64
+	// Err is the error of the tx of the item, or ErrBatchAborted if the item wasn't broadcast. The
+	// error of a new order rejected in a committed tx carries the code and the reason of the
+	// rejection, and the one of a new order without a result in the response is
+	// ErrOrderResultUnknown.
+	Err error
+}
+
+// batchChunk is a tx of a batch, covering the items [start, end) of the input
+type batchChunk struct {
+	start, end int
+	resp       types.TxResponse
+	err        error
+	release    func()
+}
+
+// NewOrdersBatch places any number of orders. They're split into txs of at most
+// common.OrderItemLimit orders, which are signed with consecutive sequences from seqNum. A result
+// is returned for every order, and the error is the one of the first failed tx.
+func (cli *OKChainClient) NewOrdersBatch(fromInfo keys.Info, orderItems []msg.OrderItem, passWd, memo string, accNum, seqNum uint64, params BatchParams) (results []BatchItemResult, err error) {
+	defer cli.metrics.measure("NewOrdersBatch", time.Now(), &err)
+	if len(orderItems) == 0 {
+		return nil, fmt.Errorf("err : no orders to place")
+	}
+	// the orders are validated at once, so an invalid one doesn't abort the batch halfway
+	if orderItems, err = cli.ValidateOrderItems(orderItems); err != nil {
+		return nil, fmt.Errorf("err : orders don't fit their products: %s", err)
+	}
+
+	return cli.runBatch(len(orderItems), orderItems, seqNum, params, func(start, end int, seq uint64, broadcastMode string) (types.TxResponse, func(), error) {
+		return cli.newOrders(fromInfo, orderItems[start:end], passWd, memo, accNum, seq, broadcastMode)
+	})
+}
+
+// CancelOrdersBatch cancels any number of orders. They're split into txs of at most
+// common.OrderItemLimit orders, which are signed with consecutive sequences from seqNum. A result
+// is returned for every order, and the error is the one of the first failed tx.
+func (cli *OKChainClient) CancelOrdersBatch(fromInfo keys.Info, passWd, memo string, orderIdList []string, accNum, seqNum uint64, params BatchParams) (results []BatchItemResult, err error) {
+	defer cli.metrics.measure("CancelOrdersBatch", time.Now(), &err)
+	if len(orderIdList) == 0 {
+		return nil, fmt.Errorf("err : no orders to cancel")
+	}
+
+	results, err = cli.runBatch(len(orderIdList), nil, seqNum, params, func(start, end int, seq uint64, broadcastMode string) (types.TxResponse, func(), error) {
+		resp, err := cli.cancelOrders(fromInfo, passWd, memo, orderIdList[start:end], accNum, seq, broadcastMode)
+		return resp, func() {}, err
+	})
+	for i := range results {
+		results[i].OrderID = orderIdList[i]
+	}
+	return results, err
+}
+
+// runBatch broadcasts the chunks of the items by the send function and maps the responses of the
+// txs back to the items. The results of the new orders are mapped if the order items are given.
+func (cli *OKChainClient) runBatch(numItems int, orderItems []msg.OrderItem, seqNum uint64, params BatchParams,
+	send func(start, end int, seq uint64, broadcastMode string) (types.TxResponse, func(), error)) ([]BatchItemResult, error) {
+	chunkSize := params.ChunkSize
+	if chunkSize == 0 {
+		chunkSize = common.OrderItemLimit
+	}
+	if chunkSize < 0 || chunkSize > common.OrderItemLimit {
+		return nil, fmt.Errorf("err : chunk size %d has to be between 1 and %d", chunkSize, common.OrderItemLimit)
+	}
+	broadcastMode := BroadcastBlock
+	if params.Pipelined {
+		broadcastMode = BroadcastSync
+	}
+
+	var chunks []*batchChunk
+	for start := 0; start < numItems; start += chunkSize {
+		end := start + chunkSize
+		if end > numItems {
+			end = numItems
+		}
+		chunk := &batchChunk{start: start, end: end}
+		chunk.resp, chunk.release, chunk.err = send(start, end, seqNum+uint64(len(chunks)), broadcastMode)
+		chunks = append(chunks, chunk)
+		if chunk.err != nil {
+			break
+		}
+	}
+
+	if params.Pipelined {
+		timeout := params.CommitTimeout
+		if timeout == 0 {
+			timeout = DefaultBatchCommitTimeout
+		}
+		for _, chunk := range chunks {
+			if chunk.err == nil {
+				chunk.resp, chunk.err = cli.waitForTx(chunk.resp.TxHash, timeout)
+			}
+		}
+	}
+
+	results := make([]BatchItemResult, numItems)
+	var firstErr error
+	for i := range results {
+		results[i] = BatchItemResult{Index: i, Err: ErrBatchAborted}
+	}
+	for _, chunk := range chunks {
+		chunk.release()
+		if chunk.err != nil && firstErr == nil {
+			firstErr = chunk.err
+		}
+		var orderResults []OrderResult
+		if chunk.err == nil && orderItems != nil {
+			orderResults = GetOrderResultsFromResponse(&chunk.resp, orderItems[chunk.start:chunk.end])
+		}
+		for i := chunk.start; i < chunk.end; i++ {
+			results[i].TxHash, results[i].Height, results[i].Err = chunk.resp.TxHash, chunk.resp.Height, chunk.err
+			if orderResults == nil {
+				continue
+			}
+			switch orderResult := orderResults[i-chunk.start]; orderResult.Status {
+			case OrderAccepted:
+				results[i].OrderID = orderResult.OrderID
+			case OrderRejected:
+				results[i].Err = fmt.Errorf("err : the order was rejected with code %d: %s", orderResult.Code, orderResult.Message)
+			default:
+				results[i].Err = ErrOrderResultUnknown
+			}
+		}
+	}
+	return results, firstErr
+}
+
+// waitForTx polls the tx of the hash until it's committed
+func (cli *OKChainClient) waitForTx(txHash string, timeout time.Duration) (types.TxResponse, error) {
+	hash, err := hex.DecodeString(txHash)
+	if err != nil {
+		return types.TxResponse{}, fmt.Errorf("err : invalid tx hash %s: %s", txHash, err)
+	}
+
+	deadline := time.Now().Add(timeout)
+	for {
+		resultTx, err := cli.QueryTx(hash, false)
+		if err == nil {
+			resp := types.NewResponseResultTx(resultTx, nil, "")
+			if resp.Code != 0 {
+				return resp, types.NewTxError(resp)
+			}
+			return resp, nil
+		}
+		if time.Now().After(deadline) {
+			return types.TxResponse{TxHash: txHash}, fmt.Errorf("err : tx %s isn't committed after %s: %s", txHash, timeout, err)
+		}
+		time.Sleep(batchPollInterval)
+	}
+}
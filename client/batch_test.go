@@ -0,0 +1,112 @@
+package client
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/okex/okchain-go-sdk/client/fakenode"
+	"github.com/okex/okchain-go-sdk/common"
+	"github.com/okex/okchain-go-sdk/types"
+	"github.com/okex/okchain-go-sdk/types/msg"
+	"github.com/okex/okchain-go-sdk/utils"
+)
+
+func TestOrdersBatch(t *testing.T) {
+	node := fakenode.New()
+	defer node.Close()
+	fromInfo, _, err := utils.CreateAccountWithMnemo(mnemonic, name, passWd)
+	if err != nil {
+		t.Fatal(err)
+	}
+	coins, _ := utils.ParseCoins("100000okt")
+	node.AddAccount(fromInfo.GetAddress(), coins)
+	node.AddProduct(types.TokenPair{BaseAssetSymbol: "xxb", QuoteAssetSymbol: "okt", MaxPriceDigit: 4, MaxQuantityDigit: 4, MinQuantity: types.NewDecWithPrec(1, 4)})
+	cli := NewClient(node.Addr())
+
+	// more orders than a tx can hold are split into txs with consecutive sequences
+	items := make([]msg.OrderItem, 2*common.OrderItemLimit+50)
+	for i := range items {
+		items[i] = msg.NewOrderItem("xxb_okt", "BUY", "0.1", "1")
+	}
+	results, err := cli.NewOrdersBatch(fromInfo, items, passWd, "", 0, 0, BatchParams{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(results) != len(items) {
+		t.Fatalf("unexpected number of results: %d", len(results))
+	}
+	orderIDs := make([]string, len(results))
+	seen := make(map[string]bool)
+	for i, result := range results {
+		if result.Index != i || result.Err != nil || result.OrderID == "" || seen[result.OrderID] {
+			t.Fatalf("unexpected result %d: %+v", i, result)
+		}
+		seen[result.OrderID] = true
+		orderIDs[i] = result.OrderID
+	}
+	if results[0].TxHash != results[common.OrderItemLimit-1].TxHash || results[0].TxHash == results[common.OrderItemLimit].TxHash {
+		t.Fatal("the orders aren't split by the limit of a tx")
+	}
+
+	// an order rejected in a tx doesn't shift the ids of the other orders of the tx
+	rejectedItems := []msg.OrderItem{
+		msg.NewOrderItem("xxb_okt", "BUY", "0.1", "1"),
+		msg.NewOrderItem("xxb_okt", "BUY", "0.1", "10000000"),
+		msg.NewOrderItem("xxb_okt", "BUY", "0.1", "2"),
+	}
+	results, err = cli.NewOrdersBatch(fromInfo, rejectedItems, passWd, "", 0, 3, BatchParams{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if results[0].Err != nil || results[2].Err != nil || results[0].OrderID == "" || results[2].OrderID == "" || results[0].OrderID == results[2].OrderID {
+		t.Fatalf("unexpected results of the accepted orders: %+v", results)
+	}
+	if results[1].Err == nil || results[1].OrderID != "" || results[1].TxHash != results[0].TxHash {
+		t.Fatalf("unexpected result of the rejected order: %+v", results[1])
+	}
+
+	// the failure of a tx aborts the following ones
+	cancelIDs := append(append([]string{}, orderIDs[:4]...), "ID0000000000-1", orderIDs[4], orderIDs[5], orderIDs[6])
+	results, err = cli.CancelOrdersBatch(fromInfo, passWd, "", cancelIDs, 0, 4, BatchParams{ChunkSize: 2})
+	var txErr *types.TxError
+	if !errors.As(err, &txErr) {
+		t.Fatalf("unexpected error of the batch: %v", err)
+	}
+	for i, result := range results {
+		if result.OrderID != cancelIDs[i] {
+			t.Fatalf("unexpected order of the result %d: %s", i, result.OrderID)
+		}
+		switch {
+		case i < 4:
+			if result.Err != nil || result.Height == 0 {
+				t.Fatalf("unexpected result %d: %+v", i, result)
+			}
+		case i < 6:
+			if !errors.As(result.Err, &txErr) || result.TxHash == "" {
+				t.Fatalf("unexpected result %d of the failed tx: %+v", i, result)
+			}
+		default:
+			if result.Err != ErrBatchAborted || result.TxHash != "" {
+				t.Fatalf("unexpected result %d of the aborted tx: %+v", i, result)
+			}
+		}
+	}
+
+	// the txs are broadcast without waiting for the blocks between them
+	results, err = cli.CancelOrdersBatch(fromInfo, passWd, "", orderIDs[4:], 0, 7, BatchParams{Pipelined: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i, result := range results {
+		if result.Err != nil || result.Height == 0 || result.OrderID != orderIDs[4+i] {
+			t.Fatalf("unexpected result %d of the pipelined batch: %+v", i, result)
+		}
+	}
+	if results[0].TxHash == results[len(results)-1].TxHash {
+		t.Fatal("the orders aren't split by the limit of a tx")
+	}
+
+	if _, err := cli.CancelOrdersBatch(fromInfo, passWd, "", cancelIDs, 0, 8, BatchParams{ChunkSize: common.OrderItemLimit + 1}); err == nil {
+		t.Fatal("a chunk beyond the limit of a tx is accepted")
+	}
+}
@@ -1,10 +1,12 @@
 package client
 
 import (
-	"errors"
 	"fmt"
 	"github.com/okex/okchain-go-sdk/types"
+	"net/http"
 
+	"github.com/okex/okchain-go-sdk/common/libs/go-metrics"
+	"github.com/okex/okchain-go-sdk/common/log"
 	"github.com/okex/okchain-go-sdk/crypto/encoding/codec"
 	cmn "github.com/tendermint/tendermint/libs/common"
 	rpcCli "github.com/tendermint/tendermint/rpc/client"
@@ -19,19 +21,89 @@ func init() {
 }
 
 type OKChainClient struct {
-	rpcUrl string
-	cli    *rpcCli.HTTP
-	cdc    *codec.Codec
+	rpcUrl  string
+	cli     *rpcCli.HTTP
+	cdc     *codec.Codec
+	network types.Network
+	metrics clientMetrics
+	logger  log.Logger
+
+	products        *productsCache
+	orderValidation OrderValidation
+	reservations    *reservations
+	balanceCheck    bool
 }
 
 func NewClient(rpcUrl string) OKChainClient {
+	return NewClientWithRegistry(rpcUrl, nil)
+}
+
+// NewClientWithRegistry creates a client which records its metrics into the given registry.
+// A new registry is created if nil is passed.
+func NewClientWithRegistry(rpcUrl string, registry metrics.Registry) OKChainClient {
+	return newClient(rpcUrl, rpcCli.NewHTTP(rpcUrl, "/websocket"), registry, types.DefaultNetwork())
+}
+
+// NewClientWithNetwork creates a client of a chain other than the default one, e.g. a testnet whose
+// chain-id and Bech32 prefixes differ. The addresses given to the client and returned by its
+// queries are encoded by the prefixes of the network, and its txs are signed for the network.
+func NewClientWithNetwork(rpcUrl string, network types.Network) (OKChainClient, error) {
+	if err := network.Validate(); err != nil {
+		return OKChainClient{}, err
+	}
+	return newClient(rpcUrl, rpcCli.NewHTTP(rpcUrl, "/websocket"), nil, network), nil
+}
+
+// NewClientWithHTTPClient creates a client which sends its rpc requests with the given http client,
+// e.g. one whose transport records or replays the requests
+func NewClientWithHTTPClient(rpcUrl string, httpClient *http.Client) OKChainClient {
+	return newClient(rpcUrl, rpcCli.NewHTTPWithClient(rpcUrl, "/websocket", httpClient), nil, types.DefaultNetwork())
+}
+
+func newClient(rpcUrl string, rpc *rpcCli.HTTP, registry metrics.Registry, network types.Network) OKChainClient {
+	clientCdc := cdc
+	if network.Codec != nil {
+		clientCdc = network.Codec
+	}
 	return OKChainClient{
-		rpcUrl: rpcUrl,
-		cli:    rpcCli.NewHTTP(rpcUrl, "/websocket"),
-		cdc:    cdc,
+		rpcUrl:  rpcUrl,
+		cli:     rpc,
+		cdc:     clientCdc,
+		network: network,
+		metrics: newClientMetrics(registry),
+		logger:  log.NewNopLogger(),
+
+		products:     newProductsCache(),
+		reservations: newReservations(),
 	}
 }
 
+// Network returns the network of the client, whose methods parse and format the addresses in its
+// Bech32 prefixes
+func (cli *OKChainClient) Network() types.Network {
+	return cli.network
+}
+
+// unmarshalJSON decodes a JSON query result of the network into types holding AccAddress fields,
+// whose Bech32 strings are converted to the prefixes of the global config first. Only the address
+// fields are converted, whether they're held as AccAddress or as strings, and the free text like
+// the description of a token stays as it is.
+func (cli *OKChainClient) unmarshalJSON(bz []byte, ptr interface{}) error {
+	bz, err := cli.network.ToDefaultJSON(bz)
+	if err != nil {
+		return err
+	}
+	return cli.cdc.UnmarshalJSON(bz, ptr)
+}
+
+// SetLogger sets the logger of the client. Nothing is logged by default.
+func (cli *OKChainClient) SetLogger(logger log.Logger) {
+	if logger == nil {
+		logger = log.NewNopLogger()
+	}
+	cli.logger = logger.With("module", "okclient")
+}
+
 func (cli *OKChainClient) query(path string, key cmn.HexBytes) ([]byte, error) {
 	opts := rpcCli.ABCIQueryOptions{
 		Height: 0,
@@ -40,14 +112,20 @@ func (cli *OKChainClient) query(path string, key cmn.HexBytes) ([]byte, error) {
 
 	result, err := cli.cli.ABCIQueryWithOptions(path, key, opts)
 	if err != nil {
+		cli.metrics.markError(errTypeRPC)
+		cli.logger.Error("abci query failed", "path", path, "err", err)
 		return nil, err
 	}
 
 	resp := result.Response
 	if !resp.IsOK() {
-		return nil, errors.New(resp.Log)
+		cli.metrics.markABCIError(resp.Codespace, resp.Code)
+		cli.logger.Error("abci query rejected", "path", path, "codespace", resp.Codespace, "code", resp.Code, "log", resp.Log)
+		return nil, types.ErrFromABCI(resp.Codespace, resp.Code, resp.Log)
 	}
 
+	cli.logger.Debug("abci query", "path", path, "height", resp.Height)
+
 	return resp.Value, nil
 
 }
@@ -64,13 +142,27 @@ func (cli *OKChainClient) broadcast(txBytes []byte, broadcastMode string) (res t
 		res, err = doBroadcastTxCommit(cli.cli, txBytes)
 	default:
 		err = fmt.Errorf("unsupported return broadcast mode %s; supported types: sync, async, block", broadcastMode)
+		return res, err
+	}
+	cli.metrics.markBroadcast(broadcastMode, res, err)
+	if err != nil {
+		cli.logger.Error("broadcast tx failed", "mode", broadcastMode, "txhash", res.TxHash, "code", res.Code, "err", err)
+	} else {
+		cli.logger.Info("broadcast tx", "mode", broadcastMode, "txhash", res.TxHash, "height", res.Height, "code", res.Code)
 	}
 	return res, err
 }
 
 func doBroadcastTxSync(cli *rpcCli.HTTP, txBytes []byte) (types.TxResponse, error) {
 	retBroadcastTx, err := cli.BroadcastTxSync(txBytes)
-	return types.NewResponseFormatBroadcastTx(retBroadcastTx), err
+	if err != nil {
+		return types.NewResponseFormatBroadcastTx(retBroadcastTx), err
+	}
+	res := types.NewResponseFormatBroadcastTx(retBroadcastTx)
+	if res.Code != 0 {
+		return res, types.NewTxError(res)
+	}
+	return res, nil
 }
 
 func doBroadcastTxAsync(cli *rpcCli.HTTP, txBytes []byte) (types.TxResponse, error) {
@@ -83,11 +175,9 @@ func doBroadcastTxCommit(cli *rpcCli.HTTP, txBytes []byte) (types.TxResponse, er
 	if err != nil {
 		return types.NewResponseFormatBroadcastTxCommit(retBroadcastTxCommit), err
 	}
-	if !retBroadcastTxCommit.CheckTx.IsOK() {
-		return types.NewResponseFormatBroadcastTxCommit(retBroadcastTxCommit), fmt.Errorf(retBroadcastTxCommit.CheckTx.Log)
-	}
-	if !retBroadcastTxCommit.DeliverTx.IsOK() {
-		return types.NewResponseFormatBroadcastTxCommit(retBroadcastTxCommit), fmt.Errorf(retBroadcastTxCommit.DeliverTx.Log)
+	res := types.NewResponseFormatBroadcastTxCommit(retBroadcastTxCommit)
+	if !retBroadcastTxCommit.CheckTx.IsOK() || !retBroadcastTxCommit.DeliverTx.IsOK() {
+		return res, types.NewTxError(res)
 	}
-	return types.NewResponseFormatBroadcastTxCommit(retBroadcastTxCommit), nil
+	return res, nil
 }
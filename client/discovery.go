@@ -0,0 +1,160 @@
+package client
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/okex/okchain-go-sdk/crypto/go-bip39"
+	"github.com/okex/okchain-go-sdk/crypto/keys/hd"
+	"github.com/okex/okchain-go-sdk/types"
+	"github.com/tendermint/tendermint/crypto"
+	"github.com/tendermint/tendermint/crypto/secp256k1"
+)
+
+// DefaultGapLimit is the number of consecutive unused addresses ending the scan of an account, as
+// recommended by BIP44
+const DefaultGapLimit = 20
+
+// DiscoveryParams are the parameters of the account discovery
+type DiscoveryParams struct {
+	// BIP39Passphrase is the optional passphrase of the mnemonic
+	BIP39Passphrase string
+	// GapLimit is the number of consecutive unused addresses ending the scan of an account. It's
+	// DefaultGapLimit if it's 0.
+	GapLimit int
+	// MaxAccounts limits the number of BIP44 accounts scanned if it's positive. The scan ends anyway
+	// at the first account without any used address.
+	MaxAccounts int
+}
+
+func (params DiscoveryParams) gapLimit() int {
+	if params.GapLimit <= 0 {
+		return DefaultGapLimit
+	}
+	return params.GapLimit
+}
+
+// DiscoveredAddress is a used address found by the account discovery
+type DiscoveredAddress struct {
+	// Path is the full BIP44 path of the address, or the path under the xpub it's derived from
+	Path    string
+	Account uint32
+	Index   uint32
+	Address types.AccAddress
+	PubKey  crypto.PubKey
+	// AccountInfo is the account on the chain, it's nil if the address only has history
+	AccountInfo types.Account
+	// HasHistory is whether the backend has transactions of the address
+	HasHistory bool
+}
+
+// DiscoverAccounts walks the BIP44 paths 44'/996'/{account}'/0/{index} of the mnemonic to find the
+// addresses used on the chain, e.g. to restore a wallet which used more than the index 0. An
+// address is used if it has an account on the chain or transactions in the backend. The scan of an
+// account ends after a gap of unused addresses and the discovery ends at the first unused account.
+func (cli *OKChainClient) DiscoverAccounts(mnemo string, params DiscoveryParams) ([]DiscoveredAddress, error) {
+	seed, err := bip39.NewSeedWithErrorChecking(mnemo, params.BIP39Passphrase)
+	if err != nil {
+		return nil, err
+	}
+	master := hd.NewMasterKey(seed)
+
+	var discovered []DiscoveredAddress
+	for account := uint32(0); params.MaxAccounts <= 0 || int(account) < params.MaxAccounts; account++ {
+		accountKey, err := master.DerivePath(fmt.Sprintf("%s%d'", hd.BIP44Prefix, account))
+		if err != nil {
+			return discovered, err
+		}
+		found, err := cli.discoverChain(accountKey.Neuter(), params.gapLimit(), func(index uint32) string {
+			return hd.NewFundraiserParams(account, index).String()
+		})
+		if err != nil {
+			return discovered, err
+		}
+		if len(found) == 0 {
+			break
+		}
+		for i := range found {
+			found[i].Account = account
+		}
+		discovered = append(discovered, found...)
+	}
+	return discovered, nil
+}
+
+// DiscoverAddressesFromXPub walks the receiving addresses 0/{index} under the extended public key of
+// an account, like the xpub of 44'/996'/0', without its private key. The scan ends after a gap of
+// unused addresses, which is DefaultGapLimit if gapLimit is 0.
+func (cli *OKChainClient) DiscoverAddressesFromXPub(xpub string, gapLimit int) ([]DiscoveredAddress, error) {
+	accountKey, err := hd.NewExtendedKeyFromString(xpub)
+	if err != nil {
+		return nil, err
+	}
+	return cli.discoverChain(accountKey.Neuter(), DiscoveryParams{GapLimit: gapLimit}.gapLimit(), func(index uint32) string {
+		return fmt.Sprintf("0/%d", index)
+	})
+}
+
+// discoverChain scans the external chain 0/{index} of the account key until gapLimit consecutive
+// addresses are unused
+func (cli *OKChainClient) discoverChain(accountKey *hd.ExtendedKey, gapLimit int, path func(index uint32) string) ([]DiscoveredAddress, error) {
+	chainKey, err := accountKey.Child(0)
+	if err != nil {
+		return nil, err
+	}
+
+	var discovered []DiscoveredAddress
+	for index, gap := uint32(0), 0; gap < gapLimit; index++ {
+		if index >= hd.HardenedKeyStart {
+			break
+		}
+		key, err := chainKey.Child(index)
+		if err == hd.ErrInvalidChild {
+			continue
+		} else if err != nil {
+			return discovered, err
+		}
+
+		var pubKey secp256k1.PubKeySecp256k1
+		copy(pubKey[:], key.PubKeyBytes())
+		addr := types.AccAddress(pubKey.Address())
+		acc, hasHistory, err := cli.addressUsage(cli.network.FormatAccAddress(addr))
+		if err != nil {
+			return discovered, err
+		}
+		if acc == nil && !hasHistory {
+			gap++
+			continue
+		}
+
+		gap = 0
+		discovered = append(discovered, DiscoveredAddress{
+			Path:        path(index),
+			Index:       index,
+			Address:     addr,
+			PubKey:      pubKey,
+			AccountInfo: acc,
+			HasHistory:  hasHistory,
+		})
+	}
+	return discovered, nil
+}
+
+// addressUsage queries the account of the address and whether it has any transaction
+func (cli *OKChainClient) addressUsage(addr string) (types.Account, bool, error) {
+	acc, err := cli.GetAccountInfoByAddr(addr)
+	if err != nil {
+		var sdkErr types.Error
+		if !errors.As(err, &sdkErr) || sdkErr.Codespace() != types.CodespaceRoot || sdkErr.Code() != types.CodeUnknownAddress {
+			return nil, false, fmt.Errorf("failed to query the account %s: %w", addr, err)
+		}
+		acc = nil
+	}
+
+	txs, err := cli.GetTransactionsInfo(addr, 0, 0, int(time.Now().Unix()), 1, 1)
+	if err != nil {
+		return nil, false, fmt.Errorf("failed to query the transactions of %s: %w", addr, err)
+	}
+	return acc, len(txs) > 0, nil
+}
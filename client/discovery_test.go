@@ -0,0 +1,81 @@
+package client
+
+import (
+	"encoding/hex"
+	"testing"
+	"time"
+
+	"github.com/okex/okchain-go-sdk/client/fakenode"
+	"github.com/okex/okchain-go-sdk/crypto/keys/hd"
+	"github.com/okex/okchain-go-sdk/types"
+	"github.com/okex/okchain-go-sdk/utils"
+	"github.com/tendermint/tendermint/crypto/secp256k1"
+)
+
+func discoveryAddr(t *testing.T, account, index uint32) types.AccAddress {
+	privateKey, err := utils.GeneratePrivateKeyFromMnemoWithParams(mnemonic, "", *hd.NewFundraiserParams(account, index))
+	if err != nil {
+		t.Fatal(err)
+	}
+	bz, err := hex.DecodeString(privateKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var privKey secp256k1.PrivKeySecp256k1
+	copy(privKey[:], bz)
+	return types.AccAddress(privKey.PubKey().Address())
+}
+
+func TestDiscoverAccounts(t *testing.T) {
+	node := fakenode.New()
+	defer node.Close()
+	coins, _ := utils.ParseCoins("1okt")
+
+	// the used addresses are 0/0 and 0/4 of the account 0, whose 0/4 only has history, and 0/1
+	// of the account 1
+	node.AddAccount(discoveryAddr(t, 0, 0), coins)
+	node.AddTransactions(types.Transaction{TxHash: "hash", Type: 1, Address: discoveryAddr(t, 0, 4).String(), Timestamp: time.Now().Unix() - 60})
+	node.AddAccount(discoveryAddr(t, 1, 1), coins)
+	// beyond the gap limit
+	node.AddAccount(discoveryAddr(t, 0, 10), coins)
+
+	okCli := NewClient(node.Addr())
+	discovered, err := okCli.DiscoverAccounts(mnemonic, DiscoveryParams{GapLimit: 5})
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := []struct {
+		path       string
+		account    uint32
+		hasAccount bool
+	}{
+		{"44'/996'/0'/0/0", 0, true},
+		{"44'/996'/0'/0/4", 0, false},
+		{"44'/996'/1'/0/1", 1, true},
+	}
+	if len(discovered) != len(expected) {
+		t.Fatalf("unexpected addresses discovered: %+v", discovered)
+	}
+	for i, e := range expected {
+		d := discovered[i]
+		if d.Path != e.path || d.Account != e.account || (d.AccountInfo != nil) != e.hasAccount || (!e.hasAccount && !d.HasHistory) {
+			t.Fatalf("unexpected address %d discovered: %+v", i, d)
+		}
+	}
+	if !discovered[1].Address.Equals(discoveryAddr(t, 0, 4)) {
+		t.Fatalf("unexpected address of %s: %s", discovered[1].Path, discovered[1].Address)
+	}
+
+	// the account 0 scanned from its xpub
+	_, xpub, err := utils.GenerateExtendedKeysFromMnemo(mnemonic, "", hd.BIP44Prefix+"0'")
+	if err != nil {
+		t.Fatal(err)
+	}
+	discovered, err = okCli.DiscoverAddressesFromXPub(xpub, 10)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(discovered) != 3 || discovered[2].Path != "0/10" || !discovered[2].Address.Equals(discoveryAddr(t, 0, 10)) {
+		t.Fatalf("unexpected addresses discovered from the xpub: %+v", discovered)
+	}
+}
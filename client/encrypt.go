@@ -0,0 +1,22 @@
+package client
+
+import (
+	"fmt"
+
+	"github.com/okex/okchain-go-sdk/crypto/ecies"
+)
+
+// EncryptToAddress encrypts the data to the public key of the account on the chain, e.g. the
+// confidential instructions of a transfer, so that only the owner of the account can decrypt it
+// by Keybase.Decrypt. The public key is only known after the account has signed a tx.
+func (cli *OKChainClient) EncryptToAddress(addr string, plaintext []byte) ([]byte, error) {
+	acc, err := cli.GetAccountInfoByAddr(addr)
+	if err != nil {
+		return nil, err
+	}
+	pubKey := acc.GetPubKey()
+	if pubKey == nil {
+		return nil, fmt.Errorf("the public key of %s is unknown since it has never signed a tx", addr)
+	}
+	return ecies.Encrypt(pubKey, plaintext)
+}
@@ -0,0 +1,42 @@
+package client
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/okex/okchain-go-sdk/client/fakenode"
+	"github.com/okex/okchain-go-sdk/utils"
+)
+
+func TestEncryptToAddress(t *testing.T) {
+	node := fakenode.New()
+	defer node.Close()
+	fromInfo, _, err := utils.CreateAccountWithMnemo(mnemonic, name, passWd)
+	if err != nil {
+		t.Fatal(err)
+	}
+	coins, _ := utils.ParseCoins("100okt")
+	node.AddAccount(fromInfo.GetAddress(), coins)
+	okCli := NewClient(node.Addr())
+
+	plaintext := []byte("confidential settlement instructions")
+	if _, err := okCli.EncryptToAddress(addr, plaintext); err == nil {
+		t.Fatal("data is encrypted to an account without a public key")
+	}
+
+	// the public key is known after a tx
+	if _, err := okCli.Send(fromInfo, passWd, addr1, "1okt", "", 0, 0); err != nil {
+		t.Fatal(err)
+	}
+	ciphertext, err := okCli.EncryptToAddress(addr, plaintext)
+	if err != nil {
+		t.Fatal(err)
+	}
+	decrypted, err := utils.DecryptWithKey(name, passWd, ciphertext)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(decrypted, plaintext) {
+		t.Fatalf("unexpected decrypted data: %s", decrypted)
+	}
+}
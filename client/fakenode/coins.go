@@ -0,0 +1,57 @@
+package fakenode
+
+import (
+	"sort"
+
+	"github.com/okex/okchain-go-sdk/types"
+)
+
+func amountOf(coins types.Coins, denom string) types.Int {
+	for _, coin := range coins {
+		if coin.Denom == denom {
+			return coin.Amount
+		}
+	}
+	return types.ZeroInt()
+}
+
+func addCoins(a, b types.Coins) types.Coins {
+	amounts := make(map[string]types.Int)
+	for _, coins := range []types.Coins{a, b} {
+		for _, coin := range coins {
+			if amount, ok := amounts[coin.Denom]; ok {
+				amounts[coin.Denom] = amount.Add(coin.Amount)
+			} else {
+				amounts[coin.Denom] = coin.Amount
+			}
+		}
+	}
+	return coinsFromAmounts(amounts)
+}
+
+// subCoins returns a-b and false if any amount of the result is negative
+func subCoins(a, b types.Coins) (types.Coins, bool) {
+	amounts := make(map[string]types.Int)
+	for _, coin := range a {
+		amounts[coin.Denom] = coin.Amount
+	}
+	for _, coin := range b {
+		amount := amountOf(a, coin.Denom).Sub(coin.Amount)
+		if amount.IsNegative() {
+			return nil, false
+		}
+		amounts[coin.Denom] = amount
+	}
+	return coinsFromAmounts(amounts), true
+}
+
+func coinsFromAmounts(amounts map[string]types.Int) types.Coins {
+	coins := types.Coins{}
+	for denom, amount := range amounts {
+		if amount.IsPositive() {
+			coins = append(coins, types.Coin{Denom: denom, Amount: amount})
+		}
+	}
+	sort.Sort(coins)
+	return coins
+}
@@ -0,0 +1,301 @@
+// Package fakenode provides an in-process OKChain node for tests. It serves the Tendermint RPC
+// used by the OKChainClient from an in-memory state: the token, order, backend and gov queries,
+// the account store and the broadcast of signed txs, which are verified and applied at once.
+package fakenode
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"time"
+
+	"github.com/okex/okchain-go-sdk/types"
+	"github.com/tendermint/go-amino"
+	"github.com/tendermint/tendermint/crypto/ed25519"
+	tmlog "github.com/tendermint/tendermint/libs/log"
+	ctypes "github.com/tendermint/tendermint/rpc/core/types"
+	rpcserver "github.com/tendermint/tendermint/rpc/lib/server"
+	rpctypes "github.com/tendermint/tendermint/rpc/lib/types"
+	tmtypes "github.com/tendermint/tendermint/types"
+)
+
+// ChainID is the chain-id of the fake node of the default network. The signatures of the txs are
+// verified against it.
+const ChainID = types.DefaultChainID
+
+// Node is an OKChain node running in process. Every accepted tx is committed into a new block
+// immediately, so all the broadcast modes see the result of the tx.
+type Node struct {
+	server  *httptest.Server
+	network types.Network
+
+	mtx        sync.Mutex
+	height     int64
+	blockTimes map[int64]time.Time
+	blockTxs   map[int64][]tmtypes.Tx
+	txs        map[string]*ctypes.ResultTx
+	validators []*tmtypes.Validator
+
+	state
+	tokens    []types.Token
+	products  []types.TokenPair
+	proposals types.Proposals
+	deals     []types.Deal
+	matches   []types.MatchResult
+	candles   [][]string
+	tickers   types.Tickers
+}
+
+// state is the part of the node modified by the txs. It's restored if a tx fails.
+type state struct {
+	accounts     map[string]*types.BaseAccount
+	nextAccNum   uint64
+	orders       []order
+	orderIndex   map[string]int
+	transactions []types.Transaction
+}
+
+// New starts a fake node of the default network listening on a random local port. It must be
+// closed after use.
+func New() *Node {
+	return NewWithNetwork(types.DefaultNetwork())
+}
+
+// NewWithNetwork starts a fake node of the network, whose chain-id the signatures are verified
+// against and whose prefixes encode the addresses of the queries
+func NewWithNetwork(network types.Network) *Node {
+	genesis := time.Now().UTC()
+	node := &Node{
+		network:    network,
+		height:     1,
+		blockTimes: map[int64]time.Time{1: genesis},
+		blockTxs:   make(map[int64][]tmtypes.Tx),
+		txs:        make(map[string]*ctypes.ResultTx),
+		validators: []*tmtypes.Validator{
+			tmtypes.NewValidator(ed25519.GenPrivKeyFromSecret([]byte(ChainID)).PubKey(), 10),
+		},
+		state: state{
+			accounts:   make(map[string]*types.BaseAccount),
+			orderIndex: make(map[string]int),
+		},
+	}
+
+	cdc := amino.NewCodec()
+	ctypes.RegisterAmino(cdc)
+	mux := http.NewServeMux()
+	rpcserver.RegisterRPCFuncs(mux, node.routes(), cdc, tmlog.NewNopLogger())
+	node.server = httptest.NewServer(mux)
+	return node
+}
+
+func (n *Node) routes() map[string]*rpcserver.RPCFunc {
+	return map[string]*rpcserver.RPCFunc{
+		"abci_query":          rpcserver.NewRPCFunc(n.abciQuery, "path,data,height,prove"),
+		"broadcast_tx_async":  rpcserver.NewRPCFunc(n.broadcastTxAsync, "tx"),
+		"broadcast_tx_sync":   rpcserver.NewRPCFunc(n.broadcastTxSync, "tx"),
+		"broadcast_tx_commit": rpcserver.NewRPCFunc(n.broadcastTxCommit, "tx"),
+		"block":               rpcserver.NewRPCFunc(n.block, "height"),
+		"tx":                  rpcserver.NewRPCFunc(n.tx, "hash,prove"),
+		"validators":          rpcserver.NewRPCFunc(n.validatorSet, "height"),
+	}
+}
+
+// Addr returns the address of the RPC server in the form expected by client.NewClient
+func (n *Node) Addr() string {
+	return n.server.Listener.Addr().String()
+}
+
+// Close shuts the RPC server down
+func (n *Node) Close() {
+	n.server.Close()
+}
+
+// Height returns the height of the latest block
+func (n *Node) Height() int64 {
+	n.mtx.Lock()
+	defer n.mtx.Unlock()
+	return n.height
+}
+
+// SkipBlocks commits the given number of empty blocks
+func (n *Node) SkipBlocks(num int64) {
+	n.mtx.Lock()
+	defer n.mtx.Unlock()
+	for i := int64(0); i < num; i++ {
+		n.commitBlock(nil)
+	}
+}
+
+// AddAccount sets the coins of an account, creating it if it doesn't exist
+func (n *Node) AddAccount(addr types.AccAddress, coins types.Coins) {
+	n.mtx.Lock()
+	defer n.mtx.Unlock()
+	n.getOrCreateAccount(addr).Coins = coins.Sort()
+}
+
+// Coins returns the available coins of an account, without the ones locked by open orders
+func (n *Node) Coins(addr types.AccAddress) types.Coins {
+	n.mtx.Lock()
+	defer n.mtx.Unlock()
+	if acc, ok := n.accounts[addr.String()]; ok {
+		return acc.Coins
+	}
+	return nil
+}
+
+// AddToken registers a token served by the token queries
+func (n *Node) AddToken(token types.Token) {
+	n.mtx.Lock()
+	defer n.mtx.Unlock()
+	n.tokens = append(n.tokens, token)
+}
+
+// AddProduct lists a token pair on the dex, so that orders can be placed on it
+func (n *Node) AddProduct(pair types.TokenPair) {
+	n.mtx.Lock()
+	defer n.mtx.Unlock()
+	n.products = append(n.products, pair)
+}
+
+// AddProposal registers a governance proposal. Its id is assigned if it's 0.
+func (n *Node) AddProposal(proposal types.Proposal) {
+	n.mtx.Lock()
+	defer n.mtx.Unlock()
+	if proposal.GetProposalID() == 0 {
+		proposal.SetProposalID(uint64(len(n.proposals) + 1))
+	}
+	n.proposals = append(n.proposals, proposal)
+}
+
+// AddDeals registers deals served by the backend queries. The fake node doesn't match orders,
+// so the deals have to be given by the tests.
+func (n *Node) AddDeals(deals ...types.Deal) {
+	n.mtx.Lock()
+	defer n.mtx.Unlock()
+	n.deals = append(n.deals, deals...)
+}
+
+// AddTransactions registers backend transactions, besides the ones recorded by the transfers and
+// the orders broadcast to the node
+func (n *Node) AddTransactions(txs ...types.Transaction) {
+	n.mtx.Lock()
+	defer n.mtx.Unlock()
+	n.transactions = append(n.transactions, txs...)
+}
+
+// AddMatchResults registers match results served by the backend queries
+func (n *Node) AddMatchResults(matches ...types.MatchResult) {
+	n.mtx.Lock()
+	defer n.mtx.Unlock()
+	n.matches = append(n.matches, matches...)
+}
+
+// SetCandles sets the candles served by the backend queries
+func (n *Node) SetCandles(candles [][]string) {
+	n.mtx.Lock()
+	defer n.mtx.Unlock()
+	n.candles = candles
+}
+
+// SetTickers sets the tickers served by the backend queries
+func (n *Node) SetTickers(tickers types.Tickers) {
+	n.mtx.Lock()
+	defer n.mtx.Unlock()
+	n.tickers = tickers
+}
+
+func (n *Node) getOrCreateAccount(addr types.AccAddress) *types.BaseAccount {
+	acc, ok := n.accounts[addr.String()]
+	if !ok {
+		acc = &types.BaseAccount{
+			Address:       addr,
+			Coins:         types.Coins{},
+			AccountNumber: n.nextAccNum,
+		}
+		n.nextAccNum++
+		n.accounts[addr.String()] = acc
+	}
+	return acc
+}
+
+// snapshot copies the state before a tx is applied
+func (s state) snapshot() state {
+	cp := state{
+		accounts:     make(map[string]*types.BaseAccount, len(s.accounts)),
+		nextAccNum:   s.nextAccNum,
+		orders:       append([]order(nil), s.orders...),
+		orderIndex:   make(map[string]int, len(s.orderIndex)),
+		transactions: append([]types.Transaction(nil), s.transactions...),
+	}
+	for addr, acc := range s.accounts {
+		accCopy := *acc
+		cp.accounts[addr] = &accCopy
+	}
+	for id, i := range s.orderIndex {
+		cp.orderIndex[id] = i
+	}
+	return cp
+}
+
+func (n *Node) commitBlock(txs []tmtypes.Tx) {
+	n.height++
+	n.blockTimes[n.height] = time.Now().UTC()
+	n.blockTxs[n.height] = txs
+}
+
+//----------------------------------------
+// node queries
+
+func (n *Node) block(ctx *rpctypes.Context, heightPtr *int64) (*ctypes.ResultBlock, error) {
+	n.mtx.Lock()
+	defer n.mtx.Unlock()
+
+	height, err := n.getHeight(heightPtr)
+	if err != nil {
+		return nil, err
+	}
+
+	block := tmtypes.MakeBlock(height, n.blockTxs[height], nil, nil)
+	block.ChainID = n.network.ChainID
+	block.Time = n.blockTimes[height]
+	block.ProposerAddress = n.validators[0].Address
+	blockMeta := tmtypes.NewBlockMeta(block, block.MakePartSet(tmtypes.BlockPartSizeBytes))
+	return &ctypes.ResultBlock{BlockMeta: blockMeta, Block: block}, nil
+}
+
+func (n *Node) tx(ctx *rpctypes.Context, hash []byte, prove bool) (*ctypes.ResultTx, error) {
+	n.mtx.Lock()
+	defer n.mtx.Unlock()
+
+	res, ok := n.txs[fmt.Sprintf("%X", hash)]
+	if !ok {
+		return nil, fmt.Errorf("tx (%X) not found", hash)
+	}
+	return res, nil
+}
+
+func (n *Node) validatorSet(ctx *rpctypes.Context, heightPtr *int64) (*ctypes.ResultValidators, error) {
+	n.mtx.Lock()
+	defer n.mtx.Unlock()
+
+	height, err := n.getHeight(heightPtr)
+	if err != nil {
+		return nil, err
+	}
+	return &ctypes.ResultValidators{BlockHeight: height, Validators: n.validators}, nil
+}
+
+func (n *Node) getHeight(heightPtr *int64) (int64, error) {
+	if heightPtr == nil {
+		return n.height, nil
+	}
+	height := *heightPtr
+	if height <= 0 {
+		return 0, fmt.Errorf("height must be greater than 0")
+	}
+	if height > n.height {
+		return 0, fmt.Errorf("height must be less than or equal to the current blockchain height")
+	}
+	return height, nil
+}
@@ -0,0 +1,460 @@
+package fakenode
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/okex/okchain-go-sdk/common"
+	"github.com/okex/okchain-go-sdk/common/queryParams"
+	"github.com/okex/okchain-go-sdk/crypto/encoding/codec"
+	"github.com/okex/okchain-go-sdk/types"
+	"github.com/okex/okchain-go-sdk/utils"
+	abci "github.com/tendermint/tendermint/abci/types"
+	cmn "github.com/tendermint/tendermint/libs/common"
+	ctypes "github.com/tendermint/tendermint/rpc/core/types"
+	rpctypes "github.com/tendermint/tendermint/rpc/lib/types"
+)
+
+const (
+	accountStorePath  = "/store/acc/key"
+	accountTokensPath = "custom/token/accounts/"
+	tokensPath        = "custom/token/tokens"
+	tokenInfoPath     = "custom/token/info/"
+	productsPath      = "custom/token/products"
+	depthbookPath     = "custom/order/depthbook"
+	candlesPath       = "custom/backend/candles"
+	tickersPath       = "custom/backend/tickers"
+	matchesPath       = "custom/backend/matches"
+	openOrdersPath    = "custom/backend/orders/open"
+	closedOrdersPath  = "custom/backend/orders/closed"
+	dealsPath         = "custom/backend/deals"
+	txsPath           = "custom/backend/txs"
+	proposalsPath     = "custom/gov/proposals"
+	proposalPath      = "custom/gov/proposal"
+)
+
+func (n *Node) abciQuery(ctx *rpctypes.Context, path string, data cmn.HexBytes, height int64, prove bool) (*ctypes.ResultABCIQuery, error) {
+	n.mtx.Lock()
+	defer n.mtx.Unlock()
+
+	value, err := n.query(path, data)
+	if err != nil {
+		return &ctypes.ResultABCIQuery{Response: abci.ResponseQuery{
+			Code:      uint32(err.Code()),
+			Codespace: string(err.Codespace()),
+			Log:       err.ABCILog(),
+			Height:    n.height,
+		}}, nil
+	}
+	return &ctypes.ResultABCIQuery{Response: abci.ResponseQuery{Value: value, Height: n.height}}, nil
+}
+
+// query answers the query like a node of the network. The addresses of the JSON params and
+// results are encoded by the prefixes of the network, while the state holds the default ones.
+func (n *Node) query(path string, data []byte) ([]byte, types.Error) {
+	if path == accountStorePath {
+		// the key and the value of the account store are binary
+		return n.queryAccount(data)
+	}
+
+	if len(data) != 0 {
+		converted, err := n.network.ToDefaultJSON(data)
+		if err != nil {
+			return nil, types.ErrUnknownRequest(fmt.Sprintf("failed to parse the query params: %s", err))
+		}
+		data = converted
+	}
+	value, err := n.queryJSON(path, data)
+	if err != nil || len(value) == 0 {
+		return value, err
+	}
+	converted, cerr := n.network.FromDefaultJSON(value)
+	if cerr != nil {
+		return nil, types.ErrInternal(cerr.Error())
+	}
+	return converted, nil
+}
+
+func (n *Node) queryJSON(path string, data []byte) ([]byte, types.Error) {
+	switch {
+	case strings.HasPrefix(path, accountTokensPath):
+		return n.queryAccountTokens(strings.TrimPrefix(path, accountTokensPath), data)
+	case path == tokensPath:
+		return marshalAmino(n.tokens)
+	case strings.HasPrefix(path, tokenInfoPath):
+		return n.queryToken(strings.TrimPrefix(path, tokenInfoPath))
+	case path == productsPath:
+		return marshalAmino(n.products)
+	case path == depthbookPath:
+		return n.queryDepthbook(data)
+	case path == candlesPath:
+		return n.queryCandles(data)
+	case path == tickersPath:
+		return n.queryTickers(data)
+	case path == matchesPath:
+		return n.queryMatches(data)
+	case path == openOrdersPath:
+		return n.queryOrders(data, true)
+	case path == closedOrdersPath:
+		return n.queryOrders(data, false)
+	case path == dealsPath:
+		return n.queryDeals(data)
+	case path == txsPath:
+		return n.queryTransactions(data)
+	case path == proposalsPath:
+		return n.queryProposals(data)
+	case path == proposalPath:
+		return n.queryProposal(data)
+	default:
+		return nil, types.ErrUnknownRequest(fmt.Sprintf("unknown query path: %s", path))
+	}
+}
+
+func (n *Node) queryAccount(key []byte) ([]byte, types.Error) {
+	prefix := utils.AddressStoreKey(nil)
+	if !bytes.HasPrefix(key, prefix) {
+		return nil, types.ErrUnknownRequest(fmt.Sprintf("invalid account store key %X", key))
+	}
+
+	acc, ok := n.accounts[types.AccAddress(key[len(prefix):]).String()]
+	if !ok {
+		// an empty value is returned by the store for the missing keys
+		return nil, nil
+	}
+	bz, err := codec.Cdc.MarshalBinaryBare(acc)
+	if err != nil {
+		return nil, types.ErrInternal(err.Error())
+	}
+	return bz, nil
+}
+
+func (n *Node) queryAccountTokens(addrStr string, data []byte) ([]byte, types.Error) {
+	addr, err := n.network.ParseAccAddress(addrStr)
+	if err != nil {
+		return nil, types.ErrInvalidAddress(addrStr)
+	}
+	var params queryParams.QueryAccTokenParams
+	if err := unmarshalParams(data, &params); err != nil {
+		return nil, err
+	}
+
+	var available types.Coins
+	if acc, ok := n.accounts[addr.String()]; ok {
+		available = acc.Coins
+	}
+	locked := n.lockedCoins(addr)
+
+	var symbols []string
+	if params.Show == "partial" {
+		symbols = []string{params.Symbol}
+	} else {
+		symbolSet := make(map[string]bool)
+		for _, token := range n.tokens {
+			symbolSet[token.Symbol] = true
+		}
+		for _, coins := range []types.Coins{available, locked} {
+			for _, coin := range coins {
+				symbolSet[coin.Denom] = true
+			}
+		}
+		for symbol := range symbolSet {
+			symbols = append(symbols, symbol)
+		}
+		sort.Strings(symbols)
+	}
+
+	info := types.AccountTokensInfo{Address: addr.String()}
+	for _, symbol := range symbols {
+		info.Currencies = append(info.Currencies, types.CoinInfo{
+			Symbol:    symbol,
+			Available: decString(amountOf(available, symbol)),
+			Freeze:    decString(types.ZeroInt()),
+			Locked:    decString(amountOf(locked, symbol)),
+		})
+	}
+	return marshalAmino(info)
+}
+
+func (n *Node) queryToken(symbol string) ([]byte, types.Error) {
+	for _, token := range n.tokens {
+		if token.Symbol == symbol {
+			return marshalAmino(token)
+		}
+	}
+	return nil, types.ErrUnknownRequest(fmt.Sprintf("unknown token %s", symbol))
+}
+
+func (n *Node) queryDepthbook(data []byte) ([]byte, types.Error) {
+	var params queryParams.QueryDepthBookParams
+	if err := unmarshalParams(data, &params); err != nil {
+		return nil, err
+	}
+	if _, ok := n.getProduct(params.Product); !ok {
+		return nil, errProductNotListed(params.Product)
+	}
+
+	asks := make(map[string]types.Dec)
+	bids := make(map[string]types.Dec)
+	for _, o := range n.orders {
+		if o.Product != params.Product || o.Status != orderStatusOpen {
+			continue
+		}
+		levels := bids
+		if o.Side == sideSell {
+			levels = asks
+		}
+		if quantity, ok := levels[o.Price]; ok {
+			levels[o.Price] = quantity.Add(o.quantity)
+		} else {
+			levels[o.Price] = o.quantity
+		}
+	}
+
+	book := types.BookRes{
+		Asks: bookItems(asks, false, params.Size),
+		Bids: bookItems(bids, true, params.Size),
+	}
+	return marshalAmino(book)
+}
+
+func bookItems(levels map[string]types.Dec, desc bool, size int) []types.BookResItem {
+	items := make([]types.BookResItem, 0, len(levels))
+	for price, quantity := range levels {
+		items = append(items, types.BookResItem{Price: price, Quantity: quantity.String()})
+	}
+	sort.Slice(items, func(i, j int) bool {
+		pi, pj := types.MustNewDecFromStr(items[i].Price), types.MustNewDecFromStr(items[j].Price)
+		if desc {
+			return pi.GT(pj)
+		}
+		return pi.LT(pj)
+	})
+	if size > 0 && len(items) > size {
+		items = items[:size]
+	}
+	return items
+}
+
+func (n *Node) queryCandles(data []byte) ([]byte, types.Error) {
+	var params queryParams.QueryKlinesParams
+	if err := unmarshalParams(data, &params); err != nil {
+		return nil, err
+	}
+
+	candles := n.candles
+	if params.Size > 0 && len(candles) > params.Size {
+		candles = candles[len(candles)-params.Size:]
+	}
+	return marshalBaseResponse(candles)
+}
+
+func (n *Node) queryTickers(data []byte) ([]byte, types.Error) {
+	var params queryParams.QueryTickerParams
+	if err := unmarshalParams(data, &params); err != nil {
+		return nil, err
+	}
+
+	var tickers types.Tickers
+	for _, ticker := range n.tickers {
+		if params.Product == "" || ticker.Product == params.Product {
+			tickers = append(tickers, ticker)
+		}
+	}
+	if params.Count > 0 && len(tickers) > params.Count {
+		tickers = tickers[:params.Count]
+	}
+	return marshalBaseResponse(tickers)
+}
+
+func (n *Node) queryMatches(data []byte) ([]byte, types.Error) {
+	var params queryParams.QueryMatchParams
+	if err := unmarshalParams(data, &params); err != nil {
+		return nil, err
+	}
+
+	var matches []types.MatchResult
+	for _, match := range n.matches {
+		if match.Product == params.Product && inTimeRange(match.Timestamp, params.Start, params.End) {
+			matches = append(matches, match)
+		}
+	}
+	page, perPage, start, end := pageRange(params.Page, params.PerPage, len(matches))
+	return marshalListResponse(matches[start:end], page, perPage, len(matches))
+}
+
+func (n *Node) queryOrders(data []byte, open bool) ([]byte, types.Error) {
+	var params queryParams.QueryOrderListParams
+	if err := unmarshalParams(data, &params); err != nil {
+		return nil, err
+	}
+
+	var orders []types.Order
+	for _, o := range n.orders {
+		if (o.Status == orderStatusOpen) != open {
+			continue
+		}
+		if o.Sender != params.Address || !matchFilter(o.Product, params.Product) || !matchFilter(o.Side, params.Side) {
+			continue
+		}
+		if inTimeRange(o.Timestamp, params.Start, params.End) {
+			orders = append(orders, o.Order)
+		}
+	}
+	page, perPage, start, end := pageRange(params.Page, params.PerPage, len(orders))
+	return marshalListResponse(orders[start:end], page, perPage, len(orders))
+}
+
+func (n *Node) queryDeals(data []byte) ([]byte, types.Error) {
+	var params queryParams.QueryDealsParams
+	if err := unmarshalParams(data, &params); err != nil {
+		return nil, err
+	}
+
+	var deals []types.Deal
+	for _, deal := range n.deals {
+		if deal.Sender != params.Address || !matchFilter(deal.Product, params.Product) || !matchFilter(deal.Side, params.Side) {
+			continue
+		}
+		if inTimeRange(deal.Timestamp, params.Start, params.End) {
+			deals = append(deals, deal)
+		}
+	}
+	page, perPage, start, end := pageRange(params.Page, params.PerPage, len(deals))
+	return marshalListResponse(deals[start:end], page, perPage, len(deals))
+}
+
+func (n *Node) queryTransactions(data []byte) ([]byte, types.Error) {
+	var params queryParams.QueryTxListParams
+	if err := unmarshalParams(data, &params); err != nil {
+		return nil, err
+	}
+
+	var txs []types.Transaction
+	for _, tx := range n.transactions {
+		if tx.Address != params.Address || (params.TxType != 0 && tx.Type != params.TxType) {
+			continue
+		}
+		if inTimeRange(tx.Timestamp, params.StartTime, params.EndTime) {
+			txs = append(txs, tx)
+		}
+	}
+	page, perPage, start, end := pageRange(params.Page, params.PerPage, len(txs))
+	return marshalListResponse(txs[start:end], page, perPage, len(txs))
+}
+
+func (n *Node) queryProposals(data []byte) ([]byte, types.Error) {
+	// the status of queryParams.QueryProposalsParams can't be decoded, so it's read as a string.
+	// No votes or deposits are kept by the fake node, so the voter and depositor are ignored.
+	var params struct {
+		ProposalStatus string
+		Limit          uint64
+	}
+	if err := unmarshalParams(data, &params); err != nil {
+		return nil, err
+	}
+
+	proposals := types.Proposals{}
+	for _, proposal := range n.proposals {
+		if params.ProposalStatus != "" && proposal.GetStatus().String() != params.ProposalStatus {
+			continue
+		}
+		proposals = append(proposals, proposal)
+	}
+	if params.Limit > 0 && uint64(len(proposals)) > params.Limit {
+		proposals = proposals[:params.Limit]
+	}
+	return marshalAmino(proposals)
+}
+
+func (n *Node) queryProposal(data []byte) ([]byte, types.Error) {
+	var params queryParams.QueryProposalParams
+	if err := unmarshalParams(data, &params); err != nil {
+		return nil, err
+	}
+
+	for _, proposal := range n.proposals {
+		if proposal.GetProposalID() == params.ProposalID {
+			return marshalAmino(proposal)
+		}
+	}
+	return nil, types.ErrUnknownRequest(fmt.Sprintf("unknown proposal %d", params.ProposalID))
+}
+
+//----------------------------------------
+// helpers
+
+func unmarshalParams(data []byte, ptr interface{}) types.Error {
+	if len(data) == 0 {
+		return nil
+	}
+	if err := codec.Cdc.UnmarshalJSON(data, ptr); err != nil {
+		return types.ErrUnknownRequest(fmt.Sprintf("failed to parse the query params: %s", err))
+	}
+	return nil
+}
+
+func marshalAmino(o interface{}) ([]byte, types.Error) {
+	bz, err := codec.Cdc.MarshalJSON(o)
+	if err != nil {
+		return nil, types.ErrInternal(err.Error())
+	}
+	return bz, nil
+}
+
+// marshalBaseResponse wraps the data like the backend module. The data has to be the last field
+// of the response, which is how the client extracts it.
+func marshalBaseResponse(data interface{}) ([]byte, types.Error) {
+	bz, err := json.Marshal(common.BaseResponse{Data: data})
+	if err != nil {
+		return nil, types.ErrInternal(err.Error())
+	}
+	return bz, nil
+}
+
+func marshalListResponse(data interface{}, page, perPage, total int) ([]byte, types.Error) {
+	bz, err := json.Marshal(common.ListResponse{
+		Data: common.ListDataRes{
+			Data:      data,
+			ParamPage: common.ParamPage{Page: page, PerPage: perPage, Total: total},
+		},
+	})
+	if err != nil {
+		return nil, types.ErrInternal(err.Error())
+	}
+	return bz, nil
+}
+
+// pageRange returns the normalized paging params and the bounds of the page in a list of
+// total items
+func pageRange(page, perPage, total int) (int, int, int, int) {
+	if page < 1 {
+		page = queryParams.DefaultPage
+	}
+	if perPage < 1 {
+		perPage = queryParams.DefaultPerPage
+	}
+	start := (page - 1) * perPage
+	if start > total {
+		start = total
+	}
+	end := start + perPage
+	if end > total {
+		end = total
+	}
+	return page, perPage, start, end
+}
+
+func inTimeRange(timestamp, start, end int64) bool {
+	return timestamp >= start && (end == 0 || timestamp <= end)
+}
+
+func matchFilter(value, filter string) bool {
+	return filter == "" || value == filter
+}
+
+func decString(amount types.Int) string {
+	return types.NewDecFromIntWithPrec(amount, types.Precision).String()
+}
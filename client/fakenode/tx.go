@@ -0,0 +1,446 @@
+package fakenode
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/okex/okchain-go-sdk/types"
+	"github.com/okex/okchain-go-sdk/types/msg"
+	"github.com/okex/okchain-go-sdk/types/tx"
+	abci "github.com/tendermint/tendermint/abci/types"
+	ctypes "github.com/tendermint/tendermint/rpc/core/types"
+	rpctypes "github.com/tendermint/tendermint/rpc/lib/types"
+	tmtypes "github.com/tendermint/tendermint/types"
+)
+
+const (
+	sideBuy  = "BUY"
+	sideSell = "SELL"
+
+	orderStatusOpen      int64 = 0
+	orderStatusCancelled int64 = 2
+
+	// types and sides of the backend transactions
+	txTypeTransfer    int64 = 1
+	txTypeNewOrder    int64 = 2
+	txTypeCancelOrder int64 = 3
+	txSideBuy         int64 = 1
+	txSideSell        int64 = 2
+	txSideFrom        int64 = 3
+	txSideTo          int64 = 4
+
+	// the signature of a tx signed with a sequence in this distance from the expected one is
+	// reported as an invalid sequence
+	maxSequenceGap = 64
+)
+
+type order struct {
+	types.Order
+	price    types.Dec
+	quantity types.Dec
+	locked   types.Coin
+}
+
+func (n *Node) broadcastTxAsync(ctx *rpctypes.Context, txBytes tmtypes.Tx) (*ctypes.ResultBroadcastTx, error) {
+	n.mtx.Lock()
+	defer n.mtx.Unlock()
+
+	n.processTx(txBytes)
+	return &ctypes.ResultBroadcastTx{Hash: txBytes.Hash()}, nil
+}
+
+func (n *Node) broadcastTxSync(ctx *rpctypes.Context, txBytes tmtypes.Tx) (*ctypes.ResultBroadcastTx, error) {
+	n.mtx.Lock()
+	defer n.mtx.Unlock()
+
+	checkRes, _ := n.processTx(txBytes)
+	return &ctypes.ResultBroadcastTx{
+		Code: checkRes.Code,
+		Data: checkRes.Data,
+		Log:  checkRes.Log,
+		Hash: txBytes.Hash(),
+	}, nil
+}
+
+func (n *Node) broadcastTxCommit(ctx *rpctypes.Context, txBytes tmtypes.Tx) (*ctypes.ResultBroadcastTxCommit, error) {
+	n.mtx.Lock()
+	defer n.mtx.Unlock()
+
+	checkRes, deliverRes := n.processTx(txBytes)
+	res := &ctypes.ResultBroadcastTxCommit{
+		CheckTx: checkRes,
+		Hash:    txBytes.Hash(),
+	}
+	if deliverRes != nil {
+		res.DeliverTx = *deliverRes
+		res.Height = n.height
+	}
+	return res, nil
+}
+
+// processTx checks the tx and delivers it in a new block if it passes the check. The result
+// of DeliverTx is nil if the tx is rejected by CheckTx.
+func (n *Node) processTx(txBytes tmtypes.Tx) (abci.ResponseCheckTx, *abci.ResponseDeliverTx) {
+	stdTx, signer, err := n.checkTx(txBytes)
+	if err != nil {
+		return abci.ResponseCheckTx{
+			Code:      uint32(err.Code()),
+			Codespace: string(err.Codespace()),
+			Log:       err.ABCILog(),
+		}, nil
+	}
+
+	n.commitBlock([]tmtypes.Tx{txBytes})
+	signer.Sequence++
+	deliverRes := n.deliverTx(txBytes, stdTx)
+	n.txs[fmt.Sprintf("%X", txBytes.Hash())] = &ctypes.ResultTx{
+		Hash:     txBytes.Hash(),
+		Height:   n.height,
+		TxResult: deliverRes,
+		Tx:       txBytes,
+	}
+	return abci.ResponseCheckTx{}, &deliverRes
+}
+
+// checkTx decodes the tx and verifies its signature like the ante handler
+func (n *Node) checkTx(txBytes []byte) (stdTx tx.StdTx, signer *types.BaseAccount, err types.Error) {
+	if decodeErr := tx.MsgCdc.UnmarshalBinaryLengthPrefixed(txBytes, &stdTx); decodeErr != nil {
+		return stdTx, nil, types.ErrTxDecode(decodeErr.Error())
+	}
+	if len(stdTx.Msgs) == 0 {
+		return stdTx, nil, types.ErrUnknownRequest("tx has no msgs")
+	}
+
+	var signerAddr types.AccAddress
+	for _, m := range stdTx.Msgs {
+		if err := m.ValidateBasic(); err != nil {
+			return stdTx, nil, err
+		}
+		addr := signerOf(m)
+		if addr.Empty() {
+			return stdTx, nil, types.ErrUnknownRequest(fmt.Sprintf("unrecognized msg type: %T", m))
+		}
+		if signerAddr != nil && !signerAddr.Equals(addr) {
+			return stdTx, nil, types.ErrUnauthorized("the msgs of the tx have different signers")
+		}
+		signerAddr = addr
+	}
+
+	switch {
+	case len(stdTx.Signatures) == 0:
+		return stdTx, nil, types.ErrNoSignatures("no signers")
+	case len(stdTx.Signatures) > 1:
+		return stdTx, nil, types.ErrUnauthorized("wrong number of signers")
+	}
+
+	signer, ok := n.accounts[signerAddr.String()]
+	if !ok {
+		return stdTx, nil, types.ErrUnknownAddress(fmt.Sprintf("account %s does not exist", signerAddr))
+	}
+
+	sig := stdTx.Signatures[0]
+	if sig.PubKey == nil || !bytes.Equal(sig.PubKey.Address(), signerAddr) {
+		return stdTx, nil, types.ErrInvalidPubKey(fmt.Sprintf("PubKey does not match Signer address %s", signerAddr))
+	}
+	if !n.verifySignature(stdTx, sig, signer.AccountNumber, signer.Sequence) {
+		for seq := subSequence(signer.Sequence, maxSequenceGap); seq <= signer.Sequence+maxSequenceGap; seq++ {
+			if seq != signer.Sequence && n.verifySignature(stdTx, sig, signer.AccountNumber, seq) {
+				return stdTx, nil, types.ErrInvalidSequence(fmt.Sprintf("Invalid sequence. Got %d, expected %d", seq, signer.Sequence))
+			}
+		}
+		return stdTx, nil, types.ErrUnauthorized("signature verification failed; verify correct account number and chain-id")
+	}
+
+	if signer.PubKey == nil {
+		signer.PubKey = sig.PubKey
+	}
+	return stdTx, signer, nil
+}
+
+func (n *Node) verifySignature(stdTx tx.StdTx, sig tx.StdSignature, accNum, seq uint64) bool {
+	signBytes := tx.StdSignBytesWithNetwork(n.network, accNum, seq, stdTx.Fee, stdTx.Msgs, stdTx.Memo)
+	return sig.PubKey.VerifyBytes(signBytes, sig.Signature)
+}
+
+func subSequence(seq, gap uint64) uint64 {
+	if seq < gap {
+		return 0
+	}
+	return seq - gap
+}
+
+// signerOf returns the signer of a msg. The transfer msgs return no signers, so their sender is
+// taken instead.
+func signerOf(m types.Msg) types.AccAddress {
+	switch m := m.(type) {
+	case msg.MsgSend:
+		return m.FromAddress
+	case msg.MsgMultiSend:
+		return m.From
+	}
+	if signers := m.GetSigners(); len(signers) > 0 {
+		return signers[0]
+	}
+	return nil
+}
+
+// deliverTx applies the msgs of the tx. The state is restored if any of them fails.
+func (n *Node) deliverTx(txBytes tmtypes.Tx, stdTx tx.StdTx) abci.ResponseDeliverTx {
+	snapshot := n.state.snapshot()
+	txHash := fmt.Sprintf("%X", txBytes.Hash())
+
+	var logs types.ABCIMessageLogs
+	var events types.Events
+	for i, m := range stdTx.Msgs {
+		msgEvents, err := n.handleMsg(txHash, m)
+		if err != nil {
+			n.state = snapshot
+			logs = append(logs, types.NewABCIMessageLog(uint16(i), false, err.ABCILog(), nil))
+			return abci.ResponseDeliverTx{
+				Code:      uint32(err.Code()),
+				Codespace: string(err.Codespace()),
+				Log:       mustMarshalLogs(logs),
+			}
+		}
+		logs = append(logs, types.NewABCIMessageLog(uint16(i), true, "", msgEvents))
+		events = events.AppendEvents(msgEvents)
+	}
+
+	return abci.ResponseDeliverTx{
+		Log:    mustMarshalLogs(logs),
+		Events: events.ToABCIEvents(),
+	}
+}
+
+func mustMarshalLogs(logs types.ABCIMessageLogs) string {
+	bz, err := json.Marshal(logs)
+	if err != nil {
+		panic(err)
+	}
+	return string(bz)
+}
+
+func (n *Node) handleMsg(txHash string, m types.Msg) (types.Events, types.Error) {
+	switch m := m.(type) {
+	case msg.MsgSend:
+		return n.handleMsgSend(txHash, m.FromAddress, []types.TransferUnit{{To: m.ToAddress, Coins: m.Amount}})
+	case msg.MsgMultiSend:
+		return n.handleMsgSend(txHash, m.From, m.Transfers)
+	case msg.MsgNewOrders:
+		return n.handleMsgNewOrders(txHash, m)
+	case msg.MsgCancelOrders:
+		return n.handleMsgCancelOrders(txHash, m)
+	default:
+		return nil, types.ErrUnknownRequest(fmt.Sprintf("unrecognized msg type: %T", m))
+	}
+}
+
+func (n *Node) handleMsgSend(txHash string, from types.AccAddress, transfers []types.TransferUnit) (types.Events, types.Error) {
+	var total types.Coins
+	for _, transfer := range transfers {
+		total = addCoins(total, transfer.Coins)
+	}
+
+	sender := n.getOrCreateAccount(from)
+	rest, ok := subCoins(sender.Coins, total)
+	if !ok {
+		return nil, types.ErrInsufficientCoins(fmt.Sprintf("insufficient account funds; %s < %s", sender.Coins, total))
+	}
+	sender.Coins = rest
+
+	timestamp := n.blockTimes[n.height].Unix()
+	events := types.Events{
+		types.NewEvent(types.EventTypeMessage,
+			types.NewAttribute(types.AttributeKeyModule, "token"),
+			types.NewAttribute(types.AttributeKeySender, from.String()),
+		),
+	}
+	for _, transfer := range transfers {
+		recipient := n.getOrCreateAccount(transfer.To)
+		recipient.Coins = addCoins(recipient.Coins, transfer.Coins)
+
+		events = append(events, types.NewEvent("transfer",
+			types.NewAttribute("recipient", transfer.To.String()),
+			types.NewAttribute(types.AttributeKeyAmount, transfer.Coins.String()),
+		))
+		for _, coin := range transfer.Coins {
+			n.transactions = append(n.transactions,
+				newTransaction(txHash, txTypeTransfer, from.String(), coin.Denom, txSideFrom, decString(coin.Amount), timestamp),
+				newTransaction(txHash, txTypeTransfer, transfer.To.String(), coin.Denom, txSideTo, decString(coin.Amount), timestamp),
+			)
+		}
+	}
+	return events, nil
+}
+
+func (n *Node) handleMsgNewOrders(txHash string, m msg.MsgNewOrders) (types.Events, types.Error) {
+	sender := n.getOrCreateAccount(m.Sender)
+	timestamp := n.blockTimes[n.height].Unix()
+	event := types.NewEvent(types.EventTypeMessage,
+		types.NewAttribute(types.AttributeKeyModule, "order"),
+		types.NewAttribute(types.AttributeKeySender, m.Sender.String()),
+	)
+
+	// the items are placed one by one like the order module does, and the msg only fails if none
+	// of them is placed
+	results := make([]types.OrderItemResult, len(m.OrderItems))
+	placed := 0
+	var firstErr types.Error
+	for i, item := range m.OrderItems {
+		orderID, err := n.placeOrder(txHash, sender, m.Sender, item, timestamp)
+		if err != nil {
+			results[i] = types.OrderItemResult{Code: uint32(err.Code()), Message: fmt.Sprint(err.Data())}
+			if firstErr == nil {
+				firstErr = err
+			}
+			continue
+		}
+		results[i] = types.OrderItemResult{OrderID: orderID}
+		placed++
+		event = event.AppendAttributes(types.NewAttribute(types.AttributeKeyOrderID, orderID))
+	}
+	if placed == 0 {
+		return nil, firstErr
+	}
+
+	bz, err := json.Marshal(results)
+	if err != nil {
+		panic(err)
+	}
+	event = event.AppendAttributes(types.NewAttribute(types.AttributeKeyOrders, string(bz)))
+	return types.Events{event}, nil
+}
+
+// placeOrder locks the funds of the order item and opens the order
+func (n *Node) placeOrder(txHash string, sender *types.BaseAccount, senderAddr types.AccAddress, item msg.OrderItem, timestamp int64) (string, types.Error) {
+	pair, ok := n.getProduct(item.Product)
+	if !ok {
+		return "", errProductNotListed(item.Product)
+	}
+
+	locked := types.NewCoin(pair.BaseAssetSymbol, types.NewIntFromBigInt(item.Quantity.Int))
+	txSide := txSideSell
+	if item.Side == sideBuy {
+		locked = types.NewCoin(pair.QuoteAssetSymbol, types.NewIntFromBigInt(item.Price.Mul(item.Quantity).Int))
+		txSide = txSideBuy
+	}
+	rest, ok := subCoins(sender.Coins, types.Coins{locked})
+	if !ok {
+		return "", types.ErrInsufficientCoins(fmt.Sprintf("insufficient account funds; %s < %s", sender.Coins, locked))
+	}
+	sender.Coins = rest
+
+	orderID := n.nextOrderID()
+	n.orderIndex[orderID] = len(n.orders)
+	n.orders = append(n.orders, order{
+		Order: types.Order{
+			TxHash:         txHash,
+			OrderId:        orderID,
+			Sender:         senderAddr.String(),
+			Product:        item.Product,
+			Side:           item.Side,
+			Price:          item.Price.String(),
+			Quantity:       item.Quantity.String(),
+			Status:         orderStatusOpen,
+			FilledAvgPrice: types.ZeroDec().String(),
+			RemainQuantity: item.Quantity.String(),
+			Timestamp:      timestamp,
+		},
+		price:    item.Price,
+		quantity: item.Quantity,
+		locked:   locked,
+	})
+	n.transactions = append(n.transactions,
+		newTransaction(txHash, txTypeNewOrder, senderAddr.String(), item.Product, txSide, item.Quantity.String(), timestamp))
+	return orderID, nil
+}
+
+// nextOrderID returns the id of the next order placed in the current block, which is made of the
+// height and the number of the order in the block
+func (n *Node) nextOrderID() string {
+	prefix := fmt.Sprintf("ID%010d-", n.height)
+	num := 1
+	for _, o := range n.orders {
+		if strings.HasPrefix(o.OrderId, prefix) {
+			num++
+		}
+	}
+	return fmt.Sprintf("%s%d", prefix, num)
+}
+
+func (n *Node) handleMsgCancelOrders(txHash string, m msg.MsgCancelOrders) (types.Events, types.Error) {
+	sender := n.getOrCreateAccount(m.Sender)
+	timestamp := n.blockTimes[n.height].Unix()
+	event := types.NewEvent(types.EventTypeMessage,
+		types.NewAttribute(types.AttributeKeyModule, "order"),
+		types.NewAttribute(types.AttributeKeySender, m.Sender.String()),
+	)
+
+	for _, orderID := range m.OrderIds {
+		i, ok := n.orderIndex[orderID]
+		if !ok {
+			return nil, types.NewError(types.CodespaceOrder, types.CodeOrderNotExist, "order(%s) does not exist", orderID)
+		}
+		o := &n.orders[i]
+		if o.Sender != m.Sender.String() {
+			return nil, types.ErrUnauthorized(fmt.Sprintf("not the owner of order(%s)", orderID))
+		}
+		if o.Status != orderStatusOpen {
+			return nil, types.NewError(types.CodespaceOrder, types.CodeOrderNotOpen, "order(%s) is not open", orderID)
+		}
+
+		o.Status = orderStatusCancelled
+		sender.Coins = addCoins(sender.Coins, types.Coins{o.locked})
+		n.transactions = append(n.transactions,
+			newTransaction(txHash, txTypeCancelOrder, m.Sender.String(), o.Product, txSideOf(o.Side), o.RemainQuantity, timestamp))
+
+		event = event.AppendAttributes(types.NewAttribute(types.AttributeKeyOrderID, orderID))
+	}
+	return types.Events{event}, nil
+}
+
+func (n *Node) getProduct(product string) (types.TokenPair, bool) {
+	for _, pair := range n.products {
+		if pair.BaseAssetSymbol+"_"+pair.QuoteAssetSymbol == product {
+			return pair, true
+		}
+	}
+	return types.TokenPair{}, false
+}
+
+// lockedCoins returns the coins locked by the open orders of an account
+func (n *Node) lockedCoins(addr types.AccAddress) types.Coins {
+	var locked types.Coins
+	for _, o := range n.orders {
+		if o.Sender == addr.String() && o.Status == orderStatusOpen {
+			locked = addCoins(locked, types.Coins{o.locked})
+		}
+	}
+	return locked
+}
+
+func errProductNotListed(product string) types.Error {
+	return types.NewError(types.CodespaceOrder, types.CodeProductNotListed, "product %s is not listed on the dex", product)
+}
+
+func txSideOf(side string) int64 {
+	if side == sideBuy {
+		return txSideBuy
+	}
+	return txSideSell
+}
+
+func newTransaction(txHash string, txType int64, addr, symbol string, side int64, quantity string, timestamp int64) types.Transaction {
+	return types.Transaction{
+		TxHash:    txHash,
+		Type:      txType,
+		Address:   addr,
+		Symbol:    symbol,
+		Side:      side,
+		Quantity:  quantity,
+		Fee:       types.ZeroDec().String(),
+		Timestamp: timestamp,
+	}
+}
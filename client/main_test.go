@@ -0,0 +1,92 @@
+package client
+
+import (
+	"os"
+	"testing"
+	"time"
+
+	"github.com/okex/okchain-go-sdk/client/fakenode"
+	"github.com/okex/okchain-go-sdk/types"
+	"github.com/okex/okchain-go-sdk/utils"
+)
+
+func TestMain(m *testing.M) {
+	node := fakenode.New()
+	if err := seedNode(node); err != nil {
+		panic(err)
+	}
+	rpcUrl = node.Addr()
+
+	code := m.Run()
+	node.Close()
+	os.Exit(code)
+}
+
+// seedNode fills the fake node with the accounts, tokens, products and proposals used by the tests
+func seedNode(node *fakenode.Node) error {
+	fromInfo, _, err := utils.CreateAccountWithMnemo(mnemonic, name, passWd)
+	if err != nil {
+		return err
+	}
+	coins, err := utils.ParseCoins("100000okt,100000okb,100000xxb,100000" + testCoin)
+	if err != nil {
+		return err
+	}
+	node.AddAccount(fromInfo.GetAddress(), coins)
+
+	accAddr, err := types.AccAddressFromBech32(addr)
+	if err != nil {
+		return err
+	}
+
+	for _, symbol := range []string{baseCoin, "okb", "xxb", testCoin} {
+		node.AddToken(types.Token{
+			Desc:           symbol,
+			Symbol:         symbol,
+			OriginalSymbol: symbol,
+			WholeName:      symbol,
+			TotalSupply:    types.NewDec(1000000000),
+			Owner:          accAddr,
+			Mintable:       true,
+		})
+	}
+	for _, product := range [][2]string{{"xxb", baseCoin}, {"xxb", "okb"}, {testCoin, baseCoin}} {
+		node.AddProduct(types.TokenPair{
+			BaseAssetSymbol:  product[0],
+			QuoteAssetSymbol: product[1],
+			InitPrice:        types.OneDec(),
+			MaxPriceDigit:    4,
+			MaxQuantityDigit: 4,
+			MinQuantity:      types.NewDecWithPrec(1, 4),
+			TokenPairId:      product[0] + "_" + product[1],
+		})
+	}
+
+	now := time.Now().UTC()
+	node.AddProposal(&types.TextProposal{BasicProposal: types.BasicProposal{
+		Title:        "test proposal",
+		Description:  "proposal served by the fake node",
+		ProposalType: types.ProposalTypeText,
+		Status:       types.StatusVotingPeriod,
+		FinalTallyResult: types.TallyResult{
+			TotalBonded: types.ZeroDec(),
+			TotalVoting: types.ZeroDec(),
+			Yes:         types.ZeroDec(),
+			Abstain:     types.ZeroDec(),
+			No:          types.ZeroDec(),
+			NoWithVeto:  types.ZeroDec(),
+		},
+		SubmitTime:      now,
+		DepositEndTime:  now.Add(24 * time.Hour),
+		VotingStartTime: now,
+		VotingEndTime:   now.Add(48 * time.Hour),
+	}})
+
+	node.SetCandles([][]string{{"1572566400", "1.0000", "1.2000", "0.9000", "1.1000", "100.0000"}})
+	node.SetTickers(types.Tickers{{Symbol: "xxb_okt", Product: "xxb_okt", Price: "1.1000"}})
+	node.AddMatchResults(types.MatchResult{Timestamp: now.Unix(), BlockHeight: 1, Product: "xxb_okb", Price: 1.1, Quantity: 10})
+	node.AddDeals(types.Deal{Timestamp: now.Unix(), BlockHeight: 1, OrderId: "ID0000000001-1", Sender: addr, Product: "xxb_okb", Side: "BUY", Price: 1.1, Quantity: 10})
+
+	node.SkipBlocks(1024)
+	return nil
+}
@@ -0,0 +1,78 @@
+package client
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/okex/okchain-go-sdk/common/libs/go-metrics"
+	"github.com/okex/okchain-go-sdk/types"
+)
+
+// metric names registered by the client
+const (
+	metricMethodPrefix    = "method."
+	metricErrorPrefix     = "errors."
+	metricBroadcastPrefix = "broadcast."
+	metricInvalidSequence = "tx.rejected.invalid_sequence"
+
+	errTypeRPC = "rpc"
+)
+
+// clientMetrics records the instrumentation of an OKChainClient into a go-metrics registry
+type clientMetrics struct {
+	registry metrics.Registry
+}
+
+func newClientMetrics(r metrics.Registry) clientMetrics {
+	if r == nil {
+		r = metrics.NewRegistry()
+	}
+	return clientMetrics{registry: r}
+}
+
+// measure updates the timer of the method and counts the error if there is one.
+// It's supposed to be deferred at the beginning of an api function.
+func (m clientMetrics) measure(method string, start time.Time, err *error) {
+	metrics.GetOrRegisterTimer(metricMethodPrefix+method, m.registry).UpdateSince(start)
+	if err != nil && *err != nil {
+		metrics.GetOrRegisterCounter(metricMethodPrefix+method+".errors", m.registry).Inc(1)
+	}
+}
+
+// markError counts an error by its type
+func (m clientMetrics) markError(errType string) {
+	metrics.GetOrRegisterCounter(metricErrorPrefix+errType, m.registry).Inc(1)
+}
+
+// markABCIError counts an error returned by the chain by its codespace and code
+func (m clientMetrics) markABCIError(codespace string, code uint32) {
+	if codespace == "" {
+		codespace = string(types.CodespaceRoot)
+	}
+	m.markError(fmt.Sprintf("abci.%s.%d", codespace, code))
+
+	// the client doesn't resync the sequence, the caller has to query the account again
+	if codespace == string(types.CodespaceRoot) && types.CodeType(code) == types.CodeInvalidSequence {
+		metrics.GetOrRegisterCounter(metricInvalidSequence, m.registry).Inc(1)
+	}
+}
+
+// markBroadcast counts the outcome of a broadcast in the given mode
+func (m clientMetrics) markBroadcast(mode string, res types.TxResponse, err error) {
+	outcome := "success"
+	if err != nil || res.Code != 0 {
+		outcome = "failure"
+	}
+	metrics.GetOrRegisterCounter(metricBroadcastPrefix+mode+"."+outcome, m.registry).Inc(1)
+
+	if res.Code != 0 {
+		m.markABCIError(res.Codespace, res.Code)
+	} else if err != nil {
+		m.markError(errTypeRPC)
+	}
+}
+
+// Metrics returns the registry holding the instrumentation of the client
+func (cli *OKChainClient) Metrics() metrics.Registry {
+	return cli.metrics.registry
+}
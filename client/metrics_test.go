@@ -0,0 +1,38 @@
+package client
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/okex/okchain-go-sdk/common/libs/go-metrics"
+)
+
+func TestClientMetrics(t *testing.T) {
+	registry := metrics.NewRegistry()
+	cli := NewClientWithRegistry(rpcUrl, registry)
+	assertNotEqual(t, cli.Metrics(), registry)
+
+	// invalid address fails before the request is sent to the node
+	_, err := cli.GetTokensInfoByAddr("invalid address")
+	assertEqual(t, err, nil)
+
+	timer, ok := registry.Get("method.GetTokensInfoByAddr").(metrics.Timer)
+	assertNotEqual(t, ok, true)
+	assertNotEqual(t, timer.Count(), int64(1))
+
+	errCounter, ok := registry.Get("method.GetTokensInfoByAddr.errors").(metrics.Counter)
+	assertNotEqual(t, ok, true)
+	assertNotEqual(t, errCounter.Count(), int64(1))
+
+	cli.metrics.markABCIError("", 3)
+	invalidSequence, ok := registry.Get("tx.rejected.invalid_sequence").(metrics.Counter)
+	assertNotEqual(t, ok, true)
+	assertNotEqual(t, invalidSequence.Count(), int64(1))
+
+	var buf bytes.Buffer
+	err = metrics.Prometheus(registry, "okchain_client", &buf)
+	assertNotEqual(t, err, nil)
+	assertNotEqual(t, strings.Contains(buf.String(), "okchain_client_method_GetTokensInfoByAddr_count 1"), true)
+	assertNotEqual(t, strings.Contains(buf.String(), "okchain_client_errors_abci_sdk_3 1"), true)
+}
@@ -0,0 +1,78 @@
+package client
+
+import (
+	"testing"
+
+	"github.com/okex/okchain-go-sdk/client/fakenode"
+	"github.com/okex/okchain-go-sdk/types"
+	"github.com/okex/okchain-go-sdk/utils"
+)
+
+func TestClientWithNetwork(t *testing.T) {
+	network := types.Network{
+		ChainID:        "okfork-1",
+		Bech32Prefixes: types.NewBech32Prefixes("okfork"),
+		BaseDenom:      "fork",
+	}
+	node := fakenode.NewWithNetwork(network)
+	defer node.Close()
+	fromInfo, _, err := utils.CreateAccountWithMnemo(mnemonic, name, passWd)
+	if err != nil {
+		t.Fatal(err)
+	}
+	coins, _ := utils.ParseCoins("100okt")
+	node.AddAccount(fromInfo.GetAddress(), coins)
+	// a description which happens to be a Bech32 address of the network isn't converted
+	description := network.FormatAccAddress(fromInfo.GetAddress())
+	node.AddToken(types.Token{Symbol: "okt", OriginalSymbol: "okt", Desc: description, TotalSupply: types.NewDec(100), Owner: fromInfo.GetAddress()})
+
+	if _, err := NewClientWithNetwork(node.Addr(), types.Network{ChainID: "okfork-1", Bech32Prefixes: types.Bech32Prefixes{AccountAddr: "okfork"}}); err == nil {
+		t.Fatal("a client is created with a network without all the prefixes")
+	}
+	okCli, err := NewClientWithNetwork(node.Addr(), network)
+	if err != nil {
+		t.Fatal(err)
+	}
+	forkAddr := network.FormatAccAddress(fromInfo.GetAddress())
+	toAddr, _ := types.AccAddressFromBech32("okchain1hw4r48aww06ldrfeuq2v438ujnl6alszzzqpph")
+	forkToAddr := network.FormatAccAddress(toAddr)
+
+	// the addresses are given in the prefix of the network
+	if _, err := okCli.GetAccountInfoByAddr(addr); err == nil {
+		t.Fatal("an account is queried by an address of the default network")
+	}
+	acc, err := okCli.GetAccountInfoByAddr(forkAddr)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !acc.GetAddress().Equals(fromInfo.GetAddress()) {
+		t.Fatalf("unexpected account of %s: %s", forkAddr, acc.GetAddress())
+	}
+	if _, err := okCli.GetTokensInfoByAddr(forkAddr); err != nil {
+		t.Fatal(err)
+	}
+	token, err := okCli.GetTokenInfo("okt")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !token.Owner.Equals(fromInfo.GetAddress()) || token.Desc != description {
+		t.Fatalf("unexpected owner or description of the token: %s %s", token.Owner, token.Desc)
+	}
+
+	// the txs are signed for the chain-id and the prefixes of the network
+	if _, err := okCli.Send(fromInfo, passWd, toAddr.String(), "1okt", "", 0, 0); err == nil {
+		t.Fatal("coins are sent to an address of the default network")
+	}
+	if _, err := okCli.Send(fromInfo, passWd, forkToAddr, "1okt", "", 0, 0); err != nil {
+		t.Fatal(err)
+	}
+	if received := node.Coins(toAddr); received.String() != "1.00000000okt" {
+		t.Fatalf("unexpected coins received: %s", received)
+	}
+
+	// a client of the default network signs for another chain
+	defaultCli := NewClient(node.Addr())
+	if _, err := defaultCli.Send(fromInfo, passWd, toAddr.String(), "1okt", "", 0, 1); err == nil {
+		t.Fatal("a tx signed for the default network is accepted")
+	}
+}
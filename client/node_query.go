@@ -5,6 +5,7 @@ import (
 	"github.com/okex/okchain-go-sdk/common/queryParams"
 	sdktypes "github.com/okex/okchain-go-sdk/types"
 	ctypes "github.com/tendermint/tendermint/rpc/core/types"
+	"time"
 )
 
 const (
@@ -12,7 +13,8 @@ const (
 	proposalInfoPath  = "custom/gov/proposal"
 )
 
-func (cli *OKChainClient) QueryBlock(height *int64) (*ctypes.ResultBlock, error) {
+func (cli *OKChainClient) QueryBlock(height *int64) (block *ctypes.ResultBlock, err error) {
+	defer cli.metrics.measure("QueryBlock", time.Now(), &err)
 	resp, err := cli.cli.Block(height)
 	if err != nil {
 		return nil, err
@@ -20,7 +22,8 @@ func (cli *OKChainClient) QueryBlock(height *int64) (*ctypes.ResultBlock, error)
 	return resp, nil
 }
 
-func (cli *OKChainClient) QueryTx(txHash []byte, prove bool) (*ctypes.ResultTx, error) {
+func (cli *OKChainClient) QueryTx(txHash []byte, prove bool) (tx *ctypes.ResultTx, err error) {
+	defer cli.metrics.measure("QueryTx", time.Now(), &err)
 	resp, err := cli.cli.Tx(txHash, prove)
 	if err != nil {
 		return nil, err
@@ -28,7 +31,8 @@ func (cli *OKChainClient) QueryTx(txHash []byte, prove bool) (*ctypes.ResultTx,
 	return resp, nil
 }
 
-func (cli *OKChainClient) QueryCurrentValidators() (sdktypes.ResultValidatorsOutput, error) {
+func (cli *OKChainClient) QueryCurrentValidators() (validators sdktypes.ResultValidatorsOutput, err error) {
+	defer cli.metrics.measure("QueryCurrentValidators", time.Now(), &err)
 	resp, err := cli.cli.Validators(nil)
 	if err != nil {
 		return sdktypes.ResultValidatorsOutput{}, err
@@ -42,7 +46,8 @@ func (cli *OKChainClient) QueryCurrentValidators() (sdktypes.ResultValidatorsOut
 	return outputValidatorsRes, nil
 }
 
-func (cli *OKChainClient) QueryProposals() (sdktypes.Proposals, error) {
+func (cli *OKChainClient) QueryProposals() (proposals sdktypes.Proposals, err error) {
+	defer cli.metrics.measure("QueryProposals", time.Now(), &err)
 	var proposalStatus queryParams.ProposalStatus
 	var voterAddr, depositorAddr sdktypes.AccAddress
 	var numLimit uint64
@@ -53,17 +58,18 @@ func (cli *OKChainClient) QueryProposals() (sdktypes.Proposals, error) {
 	}
 	res, err := cli.query(proposalsInfoPath, jsonBytes)
 	if err != nil {
-		return nil, fmt.Errorf("ok client query error : %s", err.Error())
+		return nil, fmt.Errorf("ok client query error : %w", err)
 	}
 	var matchingProposals sdktypes.Proposals
-	if err := cli.cdc.UnmarshalJSON(res, &matchingProposals); err != nil {
+	if err := cli.unmarshalJSON(res, &matchingProposals); err != nil {
 		return nil, fmt.Errorf("proposals unmarshaled failed : %s", err.Error())
 	}
 
 	return matchingProposals, nil
 }
 
-func (cli *OKChainClient) QueryProposalByID(proposalID uint64) (sdktypes.Proposal, error) {
+func (cli *OKChainClient) QueryProposalByID(proposalID uint64) (proposal sdktypes.Proposal, err error) {
+	defer cli.metrics.measure("QueryProposalByID", time.Now(), &err)
 	params := queryParams.NewQueryProposalParams(proposalID)
 	jsonBytes, err := cli.cdc.MarshalJSON(params)
 	if err != nil {
@@ -71,12 +77,12 @@ func (cli *OKChainClient) QueryProposalByID(proposalID uint64) (sdktypes.Proposa
 	}
 	res, err := cli.query(proposalInfoPath, jsonBytes)
 	if err != nil {
-		return nil, fmt.Errorf("ok client query error : %s", err.Error())
+		return nil, fmt.Errorf("ok client query error : %w", err)
 	}
 
 	var matchingProposal sdktypes.Proposal
 
-	if err := cli.cdc.UnmarshalJSON(res, &matchingProposal); err != nil {
+	if err := cli.unmarshalJSON(res, &matchingProposal); err != nil {
 		return nil, fmt.Errorf("proposal unmarshaled failed : %s", err.Error())
 	}
 	return matchingProposal, nil
@@ -4,6 +4,7 @@ import (
 	"encoding/hex"
 	"encoding/json"
 	"fmt"
+	"github.com/okex/okchain-go-sdk/utils"
 	"testing"
 )
 
@@ -19,8 +20,14 @@ func TestQueryBlock(t *testing.T) {
 
 func TestQueryTx(t *testing.T) {
 	cli := NewClient(rpcUrl)
+	fromInfo, _, err := utils.CreateAccountWithMnemo(mnemonic, name, passWd)
+	assertNotEqual(t, err, nil)
+	accInfo, err := cli.GetAccountInfoByAddr(fromInfo.GetAddress().String())
+	assertNotEqual(t, err, nil)
+	res, err := cli.Send(fromInfo, passWd, addr1, "1.024okt", "my memo", accInfo.GetAccountNumber(), accInfo.GetSequence())
+	assertNotEqual(t, err, nil)
 	// get tx hash bytes
-	txHash, err := hex.DecodeString("12CF714D13D9B86EDCCBE41BF55845BF96613977AFF8E503C5A5349A50841F9A")
+	txHash, err := hex.DecodeString(res.TxHash)
 	assertNotEqual(t, err, nil)
 	resp, err := cli.QueryTx(txHash, true)
 	assertNotEqual(t, err, nil)
@@ -0,0 +1,111 @@
+package client
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/okex/okchain-go-sdk/common/transactParams"
+	"github.com/okex/okchain-go-sdk/types"
+	"github.com/okex/okchain-go-sdk/types/msg"
+)
+
+// DefaultProductsCacheTTL is how long the products loaded to validate the orders are cached
+const DefaultProductsCacheTTL = 5 * time.Minute
+
+// OrderValidation is how the orders are validated against the precision rules of their products,
+// i.e. MaxPriceDigit, MaxQuantityDigit and MinQuantity of their types.TokenPair, before signing
+type OrderValidation int
+
+const (
+	// OrderValidationReject rejects the orders breaking the rules of their products
+	OrderValidationReject OrderValidation = iota
+	// OrderValidationRound rounds the price and the quantity of the orders to the digits of their
+	// products, by transactParams.RoundOrderItemWithTokenPair, and rejects the ones still breaking
+	// the rules, e.g. below the minimum quantity
+	OrderValidationRound
+	// OrderValidationNone leaves the validation to the chain
+	OrderValidationNone
+)
+
+// productsCache holds the products of the chain by their names like xxb_okt. It's shared by the
+// copies of a client.
+type productsCache struct {
+	mtx      sync.Mutex
+	ttl      time.Duration
+	loadedAt time.Time
+	products map[string]types.TokenPair
+}
+
+func newProductsCache() *productsCache {
+	return &productsCache{ttl: DefaultProductsCacheTTL}
+}
+
+// SetOrderValidation sets how the orders are validated before signing. They're rejected if they
+// break the rules of their products by default.
+func (cli *OKChainClient) SetOrderValidation(validation OrderValidation) {
+	cli.orderValidation = validation
+}
+
+// SetProductsCacheTTL sets how long the products loaded to validate the orders are cached. A
+// product which isn't cached is loaded at once anyway, e.g. a new listing.
+func (cli *OKChainClient) SetProductsCacheTTL(ttl time.Duration) {
+	cli.products.mtx.Lock()
+	defer cli.products.mtx.Unlock()
+	cli.products.ttl = ttl
+}
+
+// GetProductInfo returns the product of the name like xxb_okt from the cache of the products,
+// which is loaded by GetProductsInfo if it expires or misses the product
+func (cli *OKChainClient) GetProductInfo(product string) (types.TokenPair, error) {
+	cli.products.mtx.Lock()
+	defer cli.products.mtx.Unlock()
+
+	tokenPair, ok := cli.products.products[product]
+	if ok && time.Since(cli.products.loadedAt) < cli.products.ttl {
+		return tokenPair, nil
+	}
+
+	tokenPairs, err := cli.GetProductsInfo()
+	if err != nil {
+		return types.TokenPair{}, err
+	}
+	cli.products.products = make(map[string]types.TokenPair, len(tokenPairs))
+	for _, tokenPair := range tokenPairs {
+		cli.products.products[fmt.Sprintf("%s_%s", tokenPair.BaseAssetSymbol, tokenPair.QuoteAssetSymbol)] = tokenPair
+	}
+	cli.products.loadedAt = time.Now()
+
+	if tokenPair, ok = cli.products.products[product]; !ok {
+		return types.TokenPair{}, types.ErrUnknownRequest(fmt.Sprintf("product %s doesn't exist", product))
+	}
+	return tokenPair, nil
+}
+
+// ValidateOrderItems validates the orders against the rules of their products as set by
+// SetOrderValidation. The orders to sign are returned, which are rounded by OrderValidationRound.
+// An order breaking the rules, or of a product which doesn't exist, fails with an unknown request
+// sdk Error, while the failures to query the products are returned as they are.
+func (cli *OKChainClient) ValidateOrderItems(orderItems []msg.OrderItem) ([]msg.OrderItem, error) {
+	if cli.orderValidation == OrderValidationNone {
+		return orderItems, nil
+	}
+
+	validated := make([]msg.OrderItem, len(orderItems))
+	for i, item := range orderItems {
+		tokenPair, err := cli.GetProductInfo(item.Product)
+		if err != nil {
+			return nil, err
+		}
+		if cli.orderValidation == OrderValidationRound {
+			item, err = transactParams.RoundOrderItemWithTokenPair(item, tokenPair)
+		} else {
+			err = transactParams.CheckOrderItemWithTokenPair(item, tokenPair)
+		}
+		if err != nil {
+			return nil, types.ErrUnknownRequest(err.Error())
+		}
+		validated[i] = item
+	}
+	return validated, nil
+}
@@ -0,0 +1,80 @@
+package client
+
+import (
+	"testing"
+
+	"github.com/okex/okchain-go-sdk/client/fakenode"
+	"github.com/okex/okchain-go-sdk/types"
+	"github.com/okex/okchain-go-sdk/types/msg"
+	"github.com/okex/okchain-go-sdk/utils"
+)
+
+func TestValidateOrderItems(t *testing.T) {
+	node := fakenode.New()
+	defer node.Close()
+	node.AddProduct(types.TokenPair{
+		BaseAssetSymbol:  "xxb",
+		QuoteAssetSymbol: "okt",
+		MaxPriceDigit:    2,
+		MaxQuantityDigit: 1,
+		MinQuantity:      types.NewDecWithPrec(5, 1),
+	})
+	cli := NewClient(node.Addr())
+
+	valid := msg.NewOrderItem("xxb_okt", "BUY", "1.25", "0.5")
+	if items, err := cli.ValidateOrderItems([]msg.OrderItem{valid}); err != nil || items[0] != valid {
+		t.Fatalf("a valid order is rejected: %v", err)
+	}
+	invalid := []msg.OrderItem{
+		msg.NewOrderItem("xxb_okt", "BUY", "1.255", "1"),
+		msg.NewOrderItem("xxb_okt", "BUY", "1.25", "1.25"),
+		msg.NewOrderItem("xxb_okt", "BUY", "1.25", "0.4"),
+		msg.NewOrderItem("xxb_okb", "BUY", "1", "1"),
+	}
+	for _, item := range invalid {
+		if _, err := cli.ValidateOrderItems([]msg.OrderItem{valid, item}); err == nil {
+			t.Fatalf("an invalid order is accepted: %+v", item)
+		}
+	}
+
+	// the orders are rounded within their limits
+	cli.SetOrderValidation(OrderValidationRound)
+	items, err := cli.ValidateOrderItems([]msg.OrderItem{
+		msg.NewOrderItem("xxb_okt", "BUY", "1.259", "1.29"),
+		msg.NewOrderItem("xxb_okt", "SELL", "1.251", "1.29"),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if items[0].Price.String() != "1.25000000" || items[1].Price.String() != "1.26000000" || items[1].Quantity.String() != "1.20000000" {
+		t.Fatalf("unexpected orders rounded: %+v", items)
+	}
+	if _, err := cli.ValidateOrderItems([]msg.OrderItem{msg.NewOrderItem("xxb_okt", "BUY", "1", "0.59")}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := cli.ValidateOrderItems([]msg.OrderItem{msg.NewOrderItem("xxb_okt", "BUY", "1", "0.49")}); err == nil {
+		t.Fatal("an order rounded below the minimum quantity is accepted")
+	}
+
+	// a product listed after the products are cached is loaded
+	node.AddProduct(types.TokenPair{BaseAssetSymbol: "xxb", QuoteAssetSymbol: "okb", MaxPriceDigit: 4, MaxQuantityDigit: 4, MinQuantity: types.NewDecWithPrec(1, 4)})
+	if _, err := cli.GetProductInfo("xxb_okb"); err != nil {
+		t.Fatal(err)
+	}
+
+	// the orders are rejected before being signed
+	fromInfo, _, err := utils.CreateAccountWithMnemo(mnemonic, name, passWd)
+	if err != nil {
+		t.Fatal(err)
+	}
+	coins, _ := utils.ParseCoins("100okt")
+	node.AddAccount(fromInfo.GetAddress(), coins)
+	cli.SetOrderValidation(OrderValidationReject)
+	height := node.Height()
+	if _, err := cli.NewOrder(fromInfo, passWd, "xxb_okt", "BUY", "1.255", "1", "", 0, 0); err == nil {
+		t.Fatal("an invalid order is sent")
+	}
+	if node.Height() != height {
+		t.Fatal("an invalid order is broadcast")
+	}
+}
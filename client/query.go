@@ -8,6 +8,7 @@ import (
 	"github.com/okex/okchain-go-sdk/crypto/encoding/codec"
 	"github.com/okex/okchain-go-sdk/types"
 	"github.com/okex/okchain-go-sdk/utils"
+	"time"
 )
 
 const (
@@ -26,19 +27,20 @@ const (
 	transactionsInfoPath  = "custom/backend/txs"
 )
 
-func (cli *OKChainClient) GetAccountInfoByAddr(addr string) (types.Account, error) {
-	accAddr, err := types.AccAddressFromBech32(addr)
+func (cli *OKChainClient) GetAccountInfoByAddr(addr string) (acc types.Account, err error) {
+	defer cli.metrics.measure("GetAccountInfoByAddr", time.Now(), &err)
+	accAddr, err := cli.network.ParseAccAddress(addr)
 	if err != nil {
 		return nil, errors.New("err : AccAddress converted from Bech32 Failed")
 	}
 
 	res, err := cli.query(accountInfoPath, utils.AddressStoreKey(accAddr))
 	if err != nil {
-		return nil, fmt.Errorf("ok client query error : %s", err.Error())
+		return nil, fmt.Errorf("ok client query error : %w", err)
 	}
 
 	if res == nil {
-		return nil, errors.New("your account has no record on the chain")
+		return nil, types.ErrUnknownAddress(fmt.Sprintf("account %s has no record on the chain", addr))
 	}
 
 	var account types.Account
@@ -49,9 +51,10 @@ func (cli *OKChainClient) GetAccountInfoByAddr(addr string) (types.Account, erro
 	return account, nil
 }
 
-func (cli *OKChainClient) GetTokensInfoByAddr(addr string) (types.AccountTokensInfo, error) {
-	if !common.IsValidAccaddr(addr) {
-		return types.AccountTokensInfo{}, fmt.Errorf("err : invalid account address")
+func (cli *OKChainClient) GetTokensInfoByAddr(addr string) (tokensInfo types.AccountTokensInfo, err error) {
+	defer cli.metrics.measure("GetTokensInfoByAddr", time.Now(), &err)
+	if err := common.CheckAccAddrWithPrefix(addr, cli.network.Bech32Prefixes.AccountAddr); err != nil {
+		return types.AccountTokensInfo{}, fmt.Errorf("err : %s", err)
 	}
 
 	accountParams := queryParams.NewQueryAccTokenParams("", "all")
@@ -63,7 +66,7 @@ func (cli *OKChainClient) GetTokensInfoByAddr(addr string) (types.AccountTokensI
 
 	res, err := cli.query(accountTokensInfoPath+addr, jsonBytes)
 	if err != nil {
-		return types.AccountTokensInfo{}, fmt.Errorf("ok client query error : %s", err.Error())
+		return types.AccountTokensInfo{}, fmt.Errorf("ok client query error : %w", err)
 	}
 
 	var accTokensInfo types.AccountTokensInfo
@@ -73,7 +76,8 @@ func (cli *OKChainClient) GetTokensInfoByAddr(addr string) (types.AccountTokensI
 	return accTokensInfo, nil
 }
 
-func (cli *OKChainClient) GetTokenInfoByAddr(addr, symbol string) (types.AccountTokensInfo, error) {
+func (cli *OKChainClient) GetTokenInfoByAddr(addr, symbol string) (tokenInfo types.AccountTokensInfo, err error) {
+	defer cli.metrics.measure("GetTokenInfoByAddr", time.Now(), &err)
 	accountParams := queryParams.NewQueryAccTokenParams(symbol, "partial")
 
 	jsonBytes, err := cli.cdc.MarshalJSON(accountParams)
@@ -83,7 +87,7 @@ func (cli *OKChainClient) GetTokenInfoByAddr(addr, symbol string) (types.Account
 
 	res, err := cli.query(accountTokensInfoPath+addr, jsonBytes)
 	if err != nil {
-		return types.AccountTokensInfo{}, fmt.Errorf("ok client query error : %s", err.Error())
+		return types.AccountTokensInfo{}, fmt.Errorf("ok client query error : %w", err)
 	}
 
 	var accTokenInfo types.AccountTokensInfo
@@ -93,38 +97,41 @@ func (cli *OKChainClient) GetTokenInfoByAddr(addr, symbol string) (types.Account
 	return accTokenInfo, nil
 }
 
-func (cli *OKChainClient) GetTokensInfo() ([]types.Token, error) {
+func (cli *OKChainClient) GetTokensInfo() (tokens []types.Token, err error) {
+	defer cli.metrics.measure("GetTokensInfo", time.Now(), &err)
 	res, err := cli.query(tokensInfoPath, nil)
 	if err != nil {
-		return nil, fmt.Errorf("ok client query error : %s", err.Error())
+		return nil, fmt.Errorf("ok client query error : %w", err)
 	}
 
 	var tokensList []types.Token
-	if err = cli.cdc.UnmarshalJSON(res, &tokensList); err != nil {
+	if err = cli.unmarshalJSON(res, &tokensList); err != nil {
 		return nil, fmt.Errorf("err : %s", err.Error())
 	}
 
 	return tokensList, nil
 }
 
-func (cli *OKChainClient) GetTokenInfo(symbol string) (types.Token, error) {
+func (cli *OKChainClient) GetTokenInfo(symbol string) (tokenInfo types.Token, err error) {
+	defer cli.metrics.measure("GetTokenInfo", time.Now(), &err)
 	res, err := cli.query(tokenInfoPath+symbol, nil)
 	if err != nil {
-		return types.Token{}, fmt.Errorf("ok client query error : %s", err.Error())
+		return types.Token{}, fmt.Errorf("ok client query error : %w", err)
 	}
 
 	var token types.Token
-	if err = cli.cdc.UnmarshalJSON(res, &token); err != nil {
+	if err = cli.unmarshalJSON(res, &token); err != nil {
 		return types.Token{}, fmt.Errorf("err : %s", err.Error())
 	}
 
 	return token, nil
 }
 
-func (cli *OKChainClient) GetProductsInfo() ([]types.TokenPair, error) {
+func (cli *OKChainClient) GetProductsInfo() (products []types.TokenPair, err error) {
+	defer cli.metrics.measure("GetProductsInfo", time.Now(), &err)
 	res, err := cli.query(productsInfoPath, nil)
 	if err != nil {
-		return nil, fmt.Errorf("ok client query error : %s", err.Error())
+		return nil, fmt.Errorf("ok client query error : %w", err)
 	}
 
 	var productsList []types.TokenPair
@@ -135,7 +142,8 @@ func (cli *OKChainClient) GetProductsInfo() ([]types.TokenPair, error) {
 	return productsList, nil
 }
 
-func (cli *OKChainClient) GetDepthbookInfo(product string) (types.BookRes, error) {
+func (cli *OKChainClient) GetDepthbookInfo(product string) (book types.BookRes, err error) {
+	defer cli.metrics.measure("GetDepthbookInfo", time.Now(), &err)
 	params := queryParams.NewQueryDepthBookParams(product, 200)
 	jsonBytes, err := cli.cdc.MarshalJSON(params)
 	if err != nil {
@@ -144,7 +152,7 @@ func (cli *OKChainClient) GetDepthbookInfo(product string) (types.BookRes, error
 
 	res, err := cli.query(depthbookInfoPath, jsonBytes)
 	if err != nil {
-		return types.BookRes{}, fmt.Errorf("ok client query error : %s", err.Error())
+		return types.BookRes{}, fmt.Errorf("ok client query error : %w", err)
 	}
 
 	var depthbook types.BookRes
@@ -155,7 +163,8 @@ func (cli *OKChainClient) GetDepthbookInfo(product string) (types.BookRes, error
 	return depthbook, nil
 }
 
-func (cli *OKChainClient) GetCandlesInfo(product string, granularity, size int) ([][]string, error) {
+func (cli *OKChainClient) GetCandlesInfo(product string, granularity, size int) (candlesInfo [][]string, err error) {
+	defer cli.metrics.measure("GetCandlesInfo", time.Now(), &err)
 	params := queryParams.NewQueryKlinesParams(product, granularity, size)
 	jsonBytes, err := cli.cdc.MarshalJSON(params)
 	if err != nil {
@@ -164,7 +173,7 @@ func (cli *OKChainClient) GetCandlesInfo(product string, granularity, size int)
 
 	res, err := cli.query(candlesInfoPath, jsonBytes)
 	if err != nil {
-		return nil, fmt.Errorf("ok client query error : %s", err.Error())
+		return nil, fmt.Errorf("ok client query error : %w", err)
 	}
 
 	var candles [][]string
@@ -175,7 +184,8 @@ func (cli *OKChainClient) GetCandlesInfo(product string, granularity, size int)
 	return candles, nil
 }
 
-func (cli *OKChainClient) GetTickersInfo(count ...int) (types.Tickers, error) {
+func (cli *OKChainClient) GetTickersInfo(count ...int) (tickersInfo types.Tickers, err error) {
+	defer cli.metrics.measure("GetTickersInfo", time.Now(), &err)
 	countTmp, err := checkParamsGetTickersInfo(count)
 	if err != nil {
 		return nil, err
@@ -189,7 +199,7 @@ func (cli *OKChainClient) GetTickersInfo(count ...int) (types.Tickers, error) {
 
 	res, err := cli.query(tickersInfoPath, jsonBytes)
 	if err != nil {
-		return nil, fmt.Errorf("ok client query error : %s", err.Error())
+		return nil, fmt.Errorf("ok client query error : %w", err)
 	}
 
 	var tickers types.Tickers
@@ -200,7 +210,8 @@ func (cli *OKChainClient) GetTickersInfo(count ...int) (types.Tickers, error) {
 	return tickers, nil
 }
 
-func (cli *OKChainClient) GetRecentTxRecord(product string, start, end, page, perPage int) ([]types.MatchResult, error) {
+func (cli *OKChainClient) GetRecentTxRecord(product string, start, end, page, perPage int) (recordList []types.MatchResult, err error) {
+	defer cli.metrics.measure("GetRecentTxRecord", time.Now(), &err)
 	perPageTmp, err := checkParamsGetRecentTxRecord(product, start, end, page, perPage)
 	if err != nil {
 		return nil, err
@@ -214,7 +225,7 @@ func (cli *OKChainClient) GetRecentTxRecord(product string, start, end, page, pe
 
 	res, err := cli.query(recentTxRecordPath, jsonBytes)
 	if err != nil {
-		return nil, fmt.Errorf("ok client query error : %s", err.Error())
+		return nil, fmt.Errorf("ok client query error : %w", err)
 	}
 
 	var records []types.MatchResult
@@ -225,8 +236,9 @@ func (cli *OKChainClient) GetRecentTxRecord(product string, start, end, page, pe
 	return records, nil
 }
 
-func (cli *OKChainClient) GetOpenOrders(addr, product, side string, start, end, page, perPage int) ([]types.Order, error) {
-	perPageTmp, err := checkParamsGetOpenClosedOrders(addr, product, side, start, end, page, perPage)
+func (cli *OKChainClient) GetOpenOrders(addr, product, side string, start, end, page, perPage int) (orders []types.Order, err error) {
+	defer cli.metrics.measure("GetOpenOrders", time.Now(), &err)
+	perPageTmp, err := checkParamsGetOpenClosedOrders(cli.network.Bech32Prefixes.AccountAddr, addr, product, side, start, end, page, perPage)
 	if err != nil {
 		return nil, err
 	}
@@ -240,7 +252,7 @@ func (cli *OKChainClient) GetOpenOrders(addr, product, side string, start, end,
 
 	res, err := cli.query(openOrdersPath, jsonBytes)
 	if err != nil {
-		return nil, fmt.Errorf("ok client query error : %s", err.Error())
+		return nil, fmt.Errorf("ok client query error : %w", err)
 	}
 
 	var openOrdersList []types.Order
@@ -252,8 +264,9 @@ func (cli *OKChainClient) GetOpenOrders(addr, product, side string, start, end,
 
 }
 
-func (cli *OKChainClient) GetClosedOrders(addr, product, side string, start, end, page, perPage int) ([]types.Order, error) {
-	perPageTmp, err := checkParamsGetOpenClosedOrders(addr, product, side, start, end, page, perPage)
+func (cli *OKChainClient) GetClosedOrders(addr, product, side string, start, end, page, perPage int) (orders []types.Order, err error) {
+	defer cli.metrics.measure("GetClosedOrders", time.Now(), &err)
+	perPageTmp, err := checkParamsGetOpenClosedOrders(cli.network.Bech32Prefixes.AccountAddr, addr, product, side, start, end, page, perPage)
 	if err != nil {
 		return nil, err
 	}
@@ -267,7 +280,7 @@ func (cli *OKChainClient) GetClosedOrders(addr, product, side string, start, end
 
 	res, err := cli.query(closedOrdersPath, jsonBytes)
 	if err != nil {
-		return nil, fmt.Errorf("ok client query error : %s", err.Error())
+		return nil, fmt.Errorf("ok client query error : %w", err)
 	}
 	var closedOrdersList []types.Order
 	if err = codec.UnmarshalListResponse(res, &closedOrdersList); err != nil {
@@ -278,8 +291,9 @@ func (cli *OKChainClient) GetClosedOrders(addr, product, side string, start, end
 
 }
 
-func (cli *OKChainClient) GetDealsInfo(addr, product, side string, start, end, page, perPage int) ([]types.Deal, error) {
-	perPageTmp, err := checkParamsGetDealsInfo(addr, product, side, start, end, page, perPage)
+func (cli *OKChainClient) GetDealsInfo(addr, product, side string, start, end, page, perPage int) (deals []types.Deal, err error) {
+	defer cli.metrics.measure("GetDealsInfo", time.Now(), &err)
+	perPageTmp, err := checkParamsGetDealsInfo(cli.network.Bech32Prefixes.AccountAddr, addr, product, side, start, end, page, perPage)
 	if err != nil {
 		return nil, err
 	}
@@ -292,7 +306,7 @@ func (cli *OKChainClient) GetDealsInfo(addr, product, side string, start, end, p
 
 	res, err := cli.query(dealsInfoPath, jsonBytes)
 	if err != nil {
-		return nil, fmt.Errorf("ok client query error : %s", err.Error())
+		return nil, fmt.Errorf("ok client query error : %w", err)
 	}
 
 	var dealsInfo []types.Deal
@@ -304,8 +318,9 @@ func (cli *OKChainClient) GetDealsInfo(addr, product, side string, start, end, p
 	return dealsInfo, nil
 }
 
-func (cli *OKChainClient) GetTransactionsInfo(addr string, type_, start, end, page, perPage int) ([]types.Transaction, error) {
-	perPageTmp, err := checkParamsGetTransactionsInfo(addr, type_, start, end, page, perPage)
+func (cli *OKChainClient) GetTransactionsInfo(addr string, type_, start, end, page, perPage int) (txs []types.Transaction, err error) {
+	defer cli.metrics.measure("GetTransactionsInfo", time.Now(), &err)
+	perPageTmp, err := checkParamsGetTransactionsInfo(cli.network.Bech32Prefixes.AccountAddr, addr, type_, start, end, page, perPage)
 	if err != nil {
 		return nil, err
 	}
@@ -318,7 +333,7 @@ func (cli *OKChainClient) GetTransactionsInfo(addr string, type_, start, end, pa
 
 	res, err := cli.query(transactionsInfoPath, jsonBytes)
 	if err != nil {
-		return nil, fmt.Errorf("ok client query error : %s", err.Error())
+		return nil, fmt.Errorf("ok client query error : %w", err)
 	}
 
 	var transactionsInfo []types.Transaction
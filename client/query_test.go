@@ -7,11 +7,12 @@ import (
 )
 
 const (
-	addr   = "okchain1g7c3nvac7mjgn2m9mqllgat8wwd3aptdqket5k"
-	rpcUrl = "127.0.0.1:26657"
-
+	addr = "okchain1g7c3nvac7mjgn2m9mqllgat8wwd3aptdqket5k"
 )
 
+// rpcUrl is the address of the fake node started by TestMain
+var rpcUrl = "127.0.0.1:26657"
+
 func TestGetAccountInfoByAddr(t *testing.T) {
 	cli := NewClient(rpcUrl)
 	acc, err := cli.GetAccountInfoByAddr(addr)
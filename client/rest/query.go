@@ -0,0 +1,375 @@
+package rest
+
+import (
+	"encoding/hex"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/okex/okchain-go-sdk/common"
+	"github.com/okex/okchain-go-sdk/types"
+)
+
+func (h *Handler) queryRoutes() []route {
+	return []route{
+		newRoute("GET", "/accounts/{address}", h.queryAccount),
+		newRoute("GET", "/accounts/{address}/tokens", h.queryAccountTokens),
+		newRoute("GET", "/accounts/{address}/orders/open", h.queryOrders(false)),
+		newRoute("GET", "/accounts/{address}/orders/closed", h.queryOrders(true)),
+		newRoute("GET", "/accounts/{address}/deals", h.queryDeals),
+		newRoute("GET", "/accounts/{address}/transactions", h.queryTransactions),
+		newRoute("GET", "/tokens", h.queryTokens),
+		newRoute("GET", "/tokens/{symbol}", h.queryToken),
+		newRoute("GET", "/products", h.queryProducts),
+		newRoute("GET", "/products/{product}/depthbook", h.queryDepthbook),
+		newRoute("GET", "/products/{product}/candles", h.queryCandles),
+		newRoute("GET", "/products/{product}/matches", h.queryMatches),
+		newRoute("GET", "/tickers", h.queryTickers),
+		newRoute("GET", "/proposals", h.queryProposals),
+		newRoute("GET", "/proposals/{id}", h.queryProposal),
+		newRoute("GET", "/validators", h.queryValidators),
+		newRoute("GET", "/blocks/latest", h.queryBlock),
+		newRoute("GET", "/blocks/{height}", h.queryBlock),
+		newRoute("GET", "/txs/{hash}", h.queryTx),
+	}
+}
+
+// backendParams are the query params of the backend queries over a time range
+type backendParams struct {
+	paging
+	start, end int
+}
+
+// parseBackendParams parses the time range and the page. The end of the time range is now if it
+// isn't given.
+func parseBackendParams(r *http.Request) (params backendParams, err error) {
+	if params.paging, err = parsePaging(r); err != nil {
+		return
+	}
+	if params.start, err = intParam(r, "start", 0); err != nil {
+		return
+	}
+	if params.end, err = intParam(r, "end", int(time.Now().Unix())); err != nil {
+		return
+	}
+	if params.start < 0 || params.start > params.end {
+		return params, types.ErrUnknownRequest("start must be between 0 and end")
+	}
+	return
+}
+
+// parseOrderParams parses the product and the side of the order and deal queries
+func parseOrderParams(r *http.Request) (product, side string, err error) {
+	product, side = r.URL.Query().Get("product"), r.URL.Query().Get("side")
+	if product == "" {
+		return "", "", types.ErrUnknownRequest("product is required")
+	}
+	if !common.IsValidSide(side) {
+		return "", "", types.ErrUnknownRequest(fmt.Sprintf("side can only be BUY or SELL but got %q", side))
+	}
+	return product, side, nil
+}
+
+// checkAddress checks an account address encoded by the prefix of the network of the client
+func (h *Handler) checkAddress(addr string) error {
+	if _, err := h.network().ParseAccAddress(addr); err != nil {
+		return types.ErrInvalidAddress(fmt.Sprintf("invalid address %s: %s", addr, err))
+	}
+	return nil
+}
+
+func (h *Handler) queryAccount(w http.ResponseWriter, r *http.Request, vars map[string]string) {
+	if err := h.checkAddress(vars["address"]); err != nil {
+		writeError(w, 0, err)
+		return
+	}
+	acc, err := h.cli.GetAccountInfoByAddr(vars["address"])
+	if err != nil {
+		writeError(w, 0, err)
+		return
+	}
+	writeResult(w, acc)
+}
+
+func (h *Handler) queryAccountTokens(w http.ResponseWriter, r *http.Request, vars map[string]string) {
+	addr := vars["address"]
+	if err := h.checkAddress(addr); err != nil {
+		writeError(w, 0, err)
+		return
+	}
+
+	var tokens types.AccountTokensInfo
+	var err error
+	if symbol := r.URL.Query().Get("symbol"); symbol != "" {
+		tokens, err = h.cli.GetTokenInfoByAddr(addr, symbol)
+	} else {
+		tokens, err = h.cli.GetTokensInfoByAddr(addr)
+	}
+	if err != nil {
+		writeError(w, 0, err)
+		return
+	}
+	writeResult(w, tokens)
+}
+
+func (h *Handler) queryOrders(closed bool) handlerFunc {
+	return func(w http.ResponseWriter, r *http.Request, vars map[string]string) {
+		addr := vars["address"]
+		if err := h.checkAddress(addr); err != nil {
+			writeError(w, 0, err)
+			return
+		}
+		product, side, err := parseOrderParams(r)
+		if err != nil {
+			writeError(w, 0, err)
+			return
+		}
+		params, err := parseBackendParams(r)
+		if err != nil {
+			writeError(w, 0, err)
+			return
+		}
+
+		query := h.cli.GetOpenOrders
+		if closed {
+			query = h.cli.GetClosedOrders
+		}
+		orders, err := query(addr, product, side, params.start, params.end, params.page, params.perPage)
+		if err != nil {
+			writeError(w, 0, err)
+			return
+		}
+		writeList(w, orders, params.paging, nil)
+	}
+}
+
+func (h *Handler) queryDeals(w http.ResponseWriter, r *http.Request, vars map[string]string) {
+	addr := vars["address"]
+	if err := h.checkAddress(addr); err != nil {
+		writeError(w, 0, err)
+		return
+	}
+	product, side, err := parseOrderParams(r)
+	if err != nil {
+		writeError(w, 0, err)
+		return
+	}
+	params, err := parseBackendParams(r)
+	if err != nil {
+		writeError(w, 0, err)
+		return
+	}
+
+	deals, err := h.cli.GetDealsInfo(addr, product, side, params.start, params.end, params.page, params.perPage)
+	if err != nil {
+		writeError(w, 0, err)
+		return
+	}
+	writeList(w, deals, params.paging, nil)
+}
+
+func (h *Handler) queryTransactions(w http.ResponseWriter, r *http.Request, vars map[string]string) {
+	addr := vars["address"]
+	if err := h.checkAddress(addr); err != nil {
+		writeError(w, 0, err)
+		return
+	}
+	txType, err := intParam(r, "type", 0)
+	if err != nil {
+		writeError(w, 0, err)
+		return
+	}
+	params, err := parseBackendParams(r)
+	if err != nil {
+		writeError(w, 0, err)
+		return
+	}
+
+	txs, err := h.cli.GetTransactionsInfo(addr, txType, params.start, params.end, params.page, params.perPage)
+	if err != nil {
+		writeError(w, 0, err)
+		return
+	}
+	writeList(w, txs, params.paging, nil)
+}
+
+func (h *Handler) queryTokens(w http.ResponseWriter, r *http.Request, vars map[string]string) {
+	paging, err := parsePaging(r)
+	if err != nil {
+		writeError(w, 0, err)
+		return
+	}
+	tokens, err := h.cli.GetTokensInfo()
+	if err != nil {
+		writeError(w, 0, err)
+		return
+	}
+	total := len(tokens)
+	start, end := paging.slice(total)
+	writeList(w, tokens[start:end], paging, &total)
+}
+
+func (h *Handler) queryToken(w http.ResponseWriter, r *http.Request, vars map[string]string) {
+	token, err := h.cli.GetTokenInfo(vars["symbol"])
+	if err != nil {
+		writeError(w, 0, err)
+		return
+	}
+	writeResult(w, token)
+}
+
+func (h *Handler) queryProducts(w http.ResponseWriter, r *http.Request, vars map[string]string) {
+	paging, err := parsePaging(r)
+	if err != nil {
+		writeError(w, 0, err)
+		return
+	}
+	products, err := h.cli.GetProductsInfo()
+	if err != nil {
+		writeError(w, 0, err)
+		return
+	}
+	total := len(products)
+	start, end := paging.slice(total)
+	writeList(w, products[start:end], paging, &total)
+}
+
+func (h *Handler) queryDepthbook(w http.ResponseWriter, r *http.Request, vars map[string]string) {
+	book, err := h.cli.GetDepthbookInfo(vars["product"])
+	if err != nil {
+		writeError(w, 0, err)
+		return
+	}
+	writeResult(w, book)
+}
+
+func (h *Handler) queryCandles(w http.ResponseWriter, r *http.Request, vars map[string]string) {
+	granularity, err := intParam(r, "granularity", 60)
+	if err != nil {
+		writeError(w, 0, err)
+		return
+	}
+	size, err := intParam(r, "size", 100)
+	if err != nil {
+		writeError(w, 0, err)
+		return
+	}
+
+	candles, err := h.cli.GetCandlesInfo(vars["product"], granularity, size)
+	if err != nil {
+		writeError(w, 0, err)
+		return
+	}
+	writeResult(w, candles)
+}
+
+func (h *Handler) queryMatches(w http.ResponseWriter, r *http.Request, vars map[string]string) {
+	params, err := parseBackendParams(r)
+	if err != nil {
+		writeError(w, 0, err)
+		return
+	}
+	matches, err := h.cli.GetRecentTxRecord(vars["product"], params.start, params.end, params.page, params.perPage)
+	if err != nil {
+		writeError(w, 0, err)
+		return
+	}
+	writeList(w, matches, params.paging, nil)
+}
+
+func (h *Handler) queryTickers(w http.ResponseWriter, r *http.Request, vars map[string]string) {
+	count, err := intParam(r, "count", 100)
+	if err != nil {
+		writeError(w, 0, err)
+		return
+	}
+	if count < 0 {
+		writeError(w, 0, types.ErrUnknownRequest("count cannot be negative"))
+		return
+	}
+	tickers, err := h.cli.GetTickersInfo(count)
+	if err != nil {
+		writeError(w, 0, err)
+		return
+	}
+	writeResult(w, tickers)
+}
+
+func (h *Handler) queryProposals(w http.ResponseWriter, r *http.Request, vars map[string]string) {
+	paging, err := parsePaging(r)
+	if err != nil {
+		writeError(w, 0, err)
+		return
+	}
+	proposals, err := h.cli.QueryProposals()
+	if err != nil {
+		writeError(w, 0, err)
+		return
+	}
+	total := len(proposals)
+	start, end := paging.slice(total)
+	writeList(w, proposals[start:end], paging, &total)
+}
+
+func (h *Handler) queryProposal(w http.ResponseWriter, r *http.Request, vars map[string]string) {
+	id, err := strconv.ParseUint(vars["id"], 10, 64)
+	if err != nil {
+		writeError(w, 0, types.ErrUnknownRequest(fmt.Sprintf("invalid proposal id %q", vars["id"])))
+		return
+	}
+	proposal, err := h.cli.QueryProposalByID(id)
+	if err != nil {
+		writeError(w, 0, err)
+		return
+	}
+	writeResult(w, proposal)
+}
+
+func (h *Handler) queryValidators(w http.ResponseWriter, r *http.Request, vars map[string]string) {
+	validators, err := h.cli.QueryCurrentValidators()
+	if err != nil {
+		writeError(w, 0, err)
+		return
+	}
+	writeResult(w, validators)
+}
+
+func (h *Handler) queryBlock(w http.ResponseWriter, r *http.Request, vars map[string]string) {
+	var height *int64
+	if heightStr, ok := vars["height"]; ok {
+		v, err := strconv.ParseInt(heightStr, 10, 64)
+		if err != nil || v <= 0 {
+			writeError(w, 0, types.ErrUnknownRequest(fmt.Sprintf("invalid height %q", heightStr)))
+			return
+		}
+		height = &v
+	}
+
+	block, err := h.cli.QueryBlock(height)
+	if err != nil {
+		writeError(w, 0, err)
+		return
+	}
+	writeResult(w, block)
+}
+
+func (h *Handler) queryTx(w http.ResponseWriter, r *http.Request, vars map[string]string) {
+	hash, err := hex.DecodeString(vars["hash"])
+	if err != nil {
+		writeError(w, 0, types.ErrUnknownRequest(fmt.Sprintf("invalid tx hash %q", vars["hash"])))
+		return
+	}
+	tx, err := h.cli.QueryTx(hash, false)
+	if err != nil {
+		// the node answers an unknown hash with a rpc error
+		if strings.Contains(err.Error(), "not found") {
+			writeError(w, http.StatusNotFound, types.ErrUnknownRequest(err.Error()))
+			return
+		}
+		writeError(w, 0, err)
+		return
+	}
+	writeResult(w, tx)
+}
@@ -0,0 +1,310 @@
+// Package rest exposes an OKChainClient as a REST gateway with JSON bodies, so that the services
+// not written in Go can query OKChain and broadcast txs through the sdk:
+//
+//	cli := client.NewClient(rpcUrl)
+//	http.ListenAndServe("127.0.0.1:1317", rest.NewHandler(cli))
+//
+// The endpoints which sign txs are only served by a handler created with a keybase by
+// NewHandlerWithKeybase. The passwords of the keys are sent in the requests, so such a gateway
+// must only listen to trusted clients.
+//
+// Failures are answered with the codespace and the code of the sdk Error, e.g.
+//
+//	{"error":{"codespace":"sdk","code":10,"message":"insufficient coins"}}
+//
+// and a http status derived from them. The errors of the rpc connection to the node have no sdk
+// code, they're answered with 502 Bad Gateway.
+package rest
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/okex/okchain-go-sdk/client"
+	"github.com/okex/okchain-go-sdk/crypto/encoding/codec"
+	"github.com/okex/okchain-go-sdk/crypto/keys"
+	"github.com/okex/okchain-go-sdk/types"
+)
+
+const (
+	defaultPage    = 1
+	defaultPerPage = 50
+	maxPerPage     = 200
+)
+
+// Handler is a http.Handler serving the REST endpoints of an OKChainClient
+type Handler struct {
+	cli    client.OKChainClient
+	kb     keys.Keybase
+	routes []route
+}
+
+var _ http.Handler = (*Handler)(nil)
+
+// NewHandler creates a handler serving the queries and the broadcast of signed txs
+func NewHandler(cli client.OKChainClient) *Handler {
+	return NewHandlerWithKeybase(cli, nil)
+}
+
+// NewHandlerWithKeybase creates a handler which also signs txs with the keys of the keybase.
+// The signing endpoints are disabled if the keybase is nil.
+func NewHandlerWithKeybase(cli client.OKChainClient, kb keys.Keybase) *Handler {
+	h := &Handler{cli: cli, kb: kb}
+	h.routes = append(h.queryRoutes(), h.txRoutes()...)
+	return h
+}
+
+// network returns the network of the client, whose prefixes encode the addresses of the requests
+func (h *Handler) network() types.Network {
+	return h.cli.Network()
+}
+
+//----------------------------------------
+// routing
+
+// handlerFunc serves a request whose path matched a route, with the values of the path params
+type handlerFunc func(w http.ResponseWriter, r *http.Request, vars map[string]string)
+
+// route matches the requests of a method to a path pattern like "/accounts/{address}"
+type route struct {
+	method   string
+	segments []string
+	handler  handlerFunc
+}
+
+func newRoute(method, pattern string, handler handlerFunc) route {
+	return route{
+		method:   method,
+		segments: strings.Split(strings.Trim(pattern, "/"), "/"),
+		handler:  handler,
+	}
+}
+
+func (rt route) match(path string) (map[string]string, bool) {
+	segments := strings.Split(strings.Trim(path, "/"), "/")
+	if len(segments) != len(rt.segments) {
+		return nil, false
+	}
+	vars := make(map[string]string)
+	for i, segment := range rt.segments {
+		if strings.HasPrefix(segment, "{") && strings.HasSuffix(segment, "}") {
+			vars[segment[1:len(segment)-1]] = segments[i]
+		} else if segment != segments[i] {
+			return nil, false
+		}
+	}
+	return vars, true
+}
+
+// ServeHTTP implements the http.Handler interface
+func (h *Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	pathMatched := false
+	for _, rt := range h.routes {
+		vars, ok := rt.match(r.URL.Path)
+		if !ok {
+			continue
+		}
+		if rt.method != r.Method {
+			pathMatched = true
+			continue
+		}
+		rt.handler(w, r, vars)
+		return
+	}
+
+	if pathMatched {
+		writeError(w, http.StatusMethodNotAllowed, types.ErrUnknownRequest(fmt.Sprintf("method %s not allowed on %s", r.Method, r.URL.Path)))
+		return
+	}
+	writeError(w, http.StatusNotFound, types.ErrUnknownRequest(fmt.Sprintf("no endpoint %s", r.URL.Path)))
+}
+
+//----------------------------------------
+// responses
+
+// ErrorResponse is the body of a failed request
+type ErrorResponse struct {
+	Error ErrorBody `json:"error"`
+	// Tx is the response of a tx rejected by the chain
+	Tx *types.TxResponse `json:"tx,omitempty"`
+}
+
+// ErrorBody describes the sdk Error of a failed request. The codespace and the code are empty for
+// the errors of the rpc connection.
+type ErrorBody struct {
+	Codespace string `json:"codespace,omitempty"`
+	Code      uint32 `json:"code,omitempty"`
+	Message   string `json:"message"`
+}
+
+// ListResponse is the body of the endpoints returning a page of a list. The total is only known
+// for the lists which are paginated by the gateway.
+type ListResponse struct {
+	Data    json.RawMessage `json:"data"`
+	Page    int             `json:"page"`
+	PerPage int             `json:"per_page"`
+	Total   *int            `json:"total,omitempty"`
+}
+
+// marshalJSON encodes the value by amino like the REST server of OKChain does, unless it can't be
+// encoded by amino, e.g. because it has float fields
+func marshalJSON(v interface{}) ([]byte, error) {
+	bz, err := codec.Cdc.MarshalJSON(v)
+	if err != nil {
+		return json.Marshal(v)
+	}
+	return bz, nil
+}
+
+func writeJSON(w http.ResponseWriter, status int, bz []byte) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_, _ = w.Write(bz)
+}
+
+func writeResult(w http.ResponseWriter, v interface{}) {
+	bz, err := marshalJSON(v)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, types.ErrInternal(err.Error()))
+		return
+	}
+	writeJSON(w, http.StatusOK, bz)
+}
+
+func writeList(w http.ResponseWriter, list interface{}, paging paging, total *int) {
+	data, err := marshalJSON(list)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, types.ErrInternal(err.Error()))
+		return
+	}
+	bz, err := json.Marshal(ListResponse{Data: data, Page: paging.page, PerPage: paging.perPage, Total: total})
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, types.ErrInternal(err.Error()))
+		return
+	}
+	writeJSON(w, http.StatusOK, bz)
+}
+
+// writeError answers the request with the error. The status is derived from the sdk Error if it's 0.
+func writeError(w http.ResponseWriter, status int, err error) {
+	var resp ErrorResponse
+	var sdkErr types.Error
+	if errors.As(err, &sdkErr) {
+		resp.Error = ErrorBody{
+			Codespace: string(sdkErr.Codespace()),
+			Code:      uint32(sdkErr.Code()),
+			Message:   fmt.Sprint(sdkErr.Data()),
+		}
+	} else {
+		resp.Error = ErrorBody{Message: err.Error()}
+	}
+	if resp.Error.Message == "" {
+		resp.Error.Message = types.CodeToDefaultMsg(types.CodeType(resp.Error.Code))
+	}
+
+	var txErr *types.TxError
+	if errors.As(err, &txErr) {
+		resp.Tx = &txErr.Response
+	}
+	if status == 0 {
+		status = httpStatus(err)
+	}
+
+	bz, _ := json.Marshal(resp)
+	writeJSON(w, status, bz)
+}
+
+// httpStatus derives the http status from the sdk Error wrapped by err
+func httpStatus(err error) int {
+	var sdkErr types.Error
+	if !errors.As(err, &sdkErr) {
+		return http.StatusBadGateway
+	}
+	if types.IsErrOrderNotFound(err) {
+		return http.StatusNotFound
+	}
+	if sdkErr.Codespace() != types.CodespaceRoot {
+		return http.StatusUnprocessableEntity
+	}
+
+	switch sdkErr.Code() {
+	case types.CodeInternal:
+		return http.StatusInternalServerError
+	case types.CodeUnauthorized:
+		return http.StatusUnauthorized
+	case types.CodeUnknownAddress:
+		return http.StatusNotFound
+	case types.CodeInvalidSequence:
+		return http.StatusConflict
+	case types.CodeInsufficientFunds, types.CodeInsufficientCoins, types.CodeInsufficientFee, types.CodeOutOfGas:
+		return http.StatusUnprocessableEntity
+	default:
+		return http.StatusBadRequest
+	}
+}
+
+//----------------------------------------
+// request params
+
+// paging is the page requested by the page and per_page query params
+type paging struct {
+	page, perPage int
+}
+
+func parsePaging(r *http.Request) (paging, error) {
+	p := paging{page: defaultPage, perPage: defaultPerPage}
+	var err error
+	if p.page, err = intParam(r, "page", defaultPage); err != nil {
+		return p, err
+	}
+	if p.perPage, err = intParam(r, "per_page", defaultPerPage); err != nil {
+		return p, err
+	}
+	if p.page < 1 || p.perPage < 1 {
+		return p, types.ErrUnknownRequest("page and per_page must be positive")
+	}
+	if p.perPage > maxPerPage {
+		p.perPage = maxPerPage
+	}
+	return p, nil
+}
+
+// slice returns the bounds of the page in a list of the given length. The pages beyond the list
+// are checked before multiplying, since the offset of a huge page overflows.
+func (p paging) slice(length int) (start, end int) {
+	if p.page-1 > length/p.perPage {
+		return length, length
+	}
+	start = (p.page - 1) * p.perPage
+	if start > length {
+		start = length
+	}
+	end = start + p.perPage
+	if end > length {
+		end = length
+	}
+	return start, end
+}
+
+func intParam(r *http.Request, name string, defaultValue int) (int, error) {
+	s := r.URL.Query().Get(name)
+	if s == "" {
+		return defaultValue, nil
+	}
+	v, err := strconv.Atoi(s)
+	if err != nil {
+		return 0, types.ErrUnknownRequest(fmt.Sprintf("invalid %s %q: %s", name, s, err))
+	}
+	return v, nil
+}
+
+func decodeBody(r *http.Request, v interface{}) error {
+	if err := json.NewDecoder(r.Body).Decode(v); err != nil {
+		return types.ErrUnknownRequest(fmt.Sprintf("invalid request body: %s", err))
+	}
+	return nil
+}
@@ -0,0 +1,218 @@
+package rest_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/okex/okchain-go-sdk/client"
+	"github.com/okex/okchain-go-sdk/client/fakenode"
+	"github.com/okex/okchain-go-sdk/client/rest"
+	"github.com/okex/okchain-go-sdk/crypto/keys"
+	"github.com/okex/okchain-go-sdk/types"
+	"github.com/okex/okchain-go-sdk/types/msg"
+	"github.com/okex/okchain-go-sdk/types/tx"
+	"github.com/okex/okchain-go-sdk/utils"
+	"github.com/tendermint/tendermint/crypto"
+)
+
+const (
+	name     = "alice"
+	passWd   = "12345678"
+	mnemonic = "total lottery arena when pudding best candy until army spoil drill pool"
+	addr     = "okchain1g7c3nvac7mjgn2m9mqllgat8wwd3aptdqket5k"
+	// receiver of the transfers
+	addr1 = "okchain1hw4r48aww06ldrfeuq2v438ujnl6alszzzqpph"
+)
+
+func newTestGateway(t *testing.T, withKeybase bool) (*fakenode.Node, *httptest.Server) {
+	node := fakenode.New()
+	accAddr, _ := types.AccAddressFromBech32(addr)
+	coins, _ := utils.ParseCoins("1000okt")
+	node.AddAccount(accAddr, coins)
+	for _, symbol := range []string{"okt", "okb", "xxb"} {
+		node.AddToken(types.Token{Symbol: symbol, OriginalSymbol: symbol, TotalSupply: types.NewDec(1000000), Owner: accAddr})
+	}
+
+	cli := client.NewClient(node.Addr())
+	if !withKeybase {
+		return node, httptest.NewServer(rest.NewHandler(cli))
+	}
+	kb := keys.NewInMemory()
+	if _, err := kb.CreateAccount(name, mnemonic, "", passWd, 0, 0); err != nil {
+		t.Fatal(err)
+	}
+	return node, httptest.NewServer(rest.NewHandlerWithKeybase(cli, kb))
+}
+
+// brokenKeybase fails to sign as if the keybase couldn't be read
+type brokenKeybase struct {
+	keys.Keybase
+}
+
+func (kb brokenKeybase) Sign(name, passphrase string, msg []byte) ([]byte, crypto.PubKey, error) {
+	return nil, nil, errors.New("the keybase can't be read")
+}
+
+func do(t *testing.T, method, url string, body interface{}, expectedStatus int, resp interface{}) {
+	t.Helper()
+	var reqBody []byte
+	if body != nil {
+		var err error
+		if reqBody, err = json.Marshal(body); err != nil {
+			t.Fatal(err)
+		}
+	}
+	req, err := http.NewRequest(method, url, bytes.NewReader(reqBody))
+	if err != nil {
+		t.Fatal(err)
+	}
+	res, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer res.Body.Close()
+	bz, err := ioutil.ReadAll(res.Body)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if res.StatusCode != expectedStatus {
+		t.Fatalf("%s %s: expected status %d but got %d: %s", method, url, expectedStatus, res.StatusCode, bz)
+	}
+	if resp != nil {
+		if err := json.Unmarshal(bz, resp); err != nil {
+			t.Fatalf("%s %s: invalid response %s: %s", method, url, bz, err)
+		}
+	}
+}
+
+func TestQueries(t *testing.T) {
+	node, server := newTestGateway(t, false)
+	defer node.Close()
+	defer server.Close()
+
+	var account struct {
+		Type  string `json:"type"`
+		Value struct {
+			Address string `json:"address"`
+		} `json:"value"`
+	}
+	do(t, "GET", server.URL+"/accounts/"+addr, nil, http.StatusOK, &account)
+	if account.Value.Address != addr {
+		t.Fatalf("unexpected account: %+v", account)
+	}
+
+	var list rest.ListResponse
+	do(t, "GET", server.URL+"/tokens?page=2&per_page=2", nil, http.StatusOK, &list)
+	var tokens []types.Token
+	if err := json.Unmarshal(list.Data, &tokens); err != nil {
+		t.Fatal(err)
+	}
+	if list.Page != 2 || list.PerPage != 2 || list.Total == nil || *list.Total != 3 || len(tokens) != 1 || tokens[0].Symbol != "xxb" {
+		t.Fatalf("unexpected page of tokens: %+v %v", list, tokens)
+	}
+	// the offset of a huge page would overflow
+	for _, path := range []string{"/tokens", "/products"} {
+		do(t, "GET", server.URL+path+"?page=184467440737095518", nil, http.StatusOK, &list)
+		var items []json.RawMessage
+		if err := json.Unmarshal(list.Data, &items); err != nil || len(items) != 0 || list.Total == nil {
+			t.Fatalf("unexpected page beyond the list of %s: %+v", path, list)
+		}
+	}
+
+	var errResp rest.ErrorResponse
+	do(t, "GET", server.URL+"/accounts/"+addr1, nil, http.StatusNotFound, &errResp)
+	if errResp.Error.Codespace != string(types.CodespaceRoot) || errResp.Error.Code != uint32(types.CodeUnknownAddress) {
+		t.Fatalf("unexpected error of an unknown account: %+v", errResp)
+	}
+	do(t, "GET", server.URL+"/accounts/invalid", nil, http.StatusBadRequest, &errResp)
+	if errResp.Error.Code != uint32(types.CodeInvalidAddress) {
+		t.Fatalf("unexpected error of an invalid address: %+v", errResp)
+	}
+	do(t, "GET", server.URL+"/accounts/"+addr+"/orders/open?product=xxb_okt&side=HOLD", nil, http.StatusBadRequest, nil)
+	do(t, "GET", server.URL+"/unknown", nil, http.StatusNotFound, nil)
+	do(t, "DELETE", server.URL+"/tokens", nil, http.StatusMethodNotAllowed, nil)
+	do(t, "POST", server.URL+"/txs/send", rest.SendReq{}, http.StatusNotImplemented, nil)
+}
+
+func TestTxs(t *testing.T) {
+	node, server := newTestGateway(t, true)
+	defer node.Close()
+	defer server.Close()
+	receiver, _ := types.AccAddressFromBech32(addr1)
+
+	var resp types.TxResponse
+	sendReq := rest.SendReq{BaseReq: rest.BaseReq{Name: name, Password: passWd}, To: addr1, Amount: "10okt"}
+	do(t, "POST", server.URL+"/txs/send", sendReq, http.StatusOK, &resp)
+	if resp.TxHash == "" || node.Coins(receiver).String() != "10.00000000okt" {
+		t.Fatalf("unexpected result of the transfer: %+v %s", resp, node.Coins(receiver))
+	}
+
+	// sign by the gateway and broadcast as a pre-signed tx
+	from, _ := types.AccAddressFromBech32(addr)
+	coins, _ := utils.ParseCoins("5okt")
+	unsigned, err := tx.MsgCdc.MarshalJSON(tx.BuildUnsignedStdTx("offline", []types.Msg{msg.NewMsgTokenSend(from, receiver, coins)}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	var signed json.RawMessage
+	do(t, "POST", server.URL+"/txs/sign", rest.SignReq{BaseReq: rest.BaseReq{Name: name, Password: passWd}, Tx: unsigned}, http.StatusOK, &signed)
+	do(t, "POST", server.URL+"/txs", rest.BroadcastReq{Tx: signed}, http.StatusOK, &resp)
+	if node.Coins(receiver).String() != "15.00000000okt" {
+		t.Fatalf("unexpected coins after the pre-signed tx: %s", node.Coins(receiver))
+	}
+
+	// the errors of the chain keep their codespace and code
+	var errResp rest.ErrorResponse
+	sendReq.Amount = "100000okt"
+	do(t, "POST", server.URL+"/txs/send", sendReq, http.StatusUnprocessableEntity, &errResp)
+	if errResp.Error.Code != uint32(types.CodeInsufficientCoins) || errResp.Tx == nil || errResp.Tx.TxHash == "" {
+		t.Fatalf("unexpected error of an unaffordable transfer: %+v", errResp)
+	}
+	do(t, "POST", server.URL+"/txs", rest.BroadcastReq{Tx: signed}, http.StatusConflict, &errResp)
+	if errResp.Error.Code != uint32(types.CodeInvalidSequence) {
+		t.Fatalf("unexpected error of a replayed tx: %+v", errResp)
+	}
+	sendReq.BaseReq.Password = "wrong password"
+	do(t, "POST", server.URL+"/txs/send", sendReq, http.StatusUnauthorized, nil)
+	// only a wrong password is unauthorized
+	kb := keys.NewInMemory()
+	if _, err := kb.CreateAccount(name, mnemonic, "", passWd, 0, 0); err != nil {
+		t.Fatal(err)
+	}
+	brokenServer := httptest.NewServer(rest.NewHandlerWithKeybase(client.NewClient(node.Addr()), brokenKeybase{kb}))
+	defer brokenServer.Close()
+	sendReq.BaseReq.Password = passWd
+	do(t, "POST", brokenServer.URL+"/txs/send", sendReq, http.StatusInternalServerError, nil)
+	do(t, "POST", server.URL+"/txs", rest.BroadcastReq{Tx: json.RawMessage(unsigned)}, http.StatusBadRequest, &errResp)
+	if errResp.Error.Code != uint32(types.CodeNoSignatures) {
+		t.Fatalf("unexpected error of an unsigned tx: %+v", errResp)
+	}
+}
+
+func TestNewOrdersErrors(t *testing.T) {
+	node, server := newTestGateway(t, true)
+	defer node.Close()
+	defer server.Close()
+	node.AddProduct(types.TokenPair{BaseAssetSymbol: "xxb", QuoteAssetSymbol: "okt", MaxPriceDigit: 2, MaxQuantityDigit: 2, MinQuantity: types.NewDecWithPrec(1, 2)})
+	ordersReq := rest.NewOrdersReq{
+		BaseReq: rest.BaseReq{Name: name, Password: passWd},
+		Orders:  []rest.OrderReq{{Product: "xxb_okt", Side: "BUY", Price: "1.255", Quantity: "1"}},
+	}
+
+	// an order breaking the rules of its product is a bad request
+	var errResp rest.ErrorResponse
+	do(t, "POST", server.URL+"/txs/orders", ordersReq, http.StatusBadRequest, &errResp)
+	if errResp.Error.Code != uint32(types.CodeUnknownRequest) {
+		t.Fatalf("unexpected error of an invalid order: %+v", errResp)
+	}
+
+	// but the products missing from the cache can't be queried without the node
+	node.Close()
+	ordersReq.Orders[0].Product = "xxb_okb"
+	do(t, "POST", server.URL+"/txs/orders", ordersReq, http.StatusBadGateway, nil)
+}
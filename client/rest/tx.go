@@ -0,0 +1,357 @@
+package rest
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/okex/okchain-go-sdk/client"
+	"github.com/okex/okchain-go-sdk/crypto/keys/keyerror"
+	"github.com/okex/okchain-go-sdk/types"
+	"github.com/okex/okchain-go-sdk/types/msg"
+	"github.com/okex/okchain-go-sdk/types/tx"
+	"github.com/okex/okchain-go-sdk/utils"
+)
+
+func (h *Handler) txRoutes() []route {
+	signing := func(handler handlerFunc) handlerFunc {
+		if h.kb == nil {
+			return signingDisabled
+		}
+		return handler
+	}
+	return []route{
+		newRoute("POST", "/txs", h.broadcastTx),
+		newRoute("POST", "/txs/sign", signing(h.signTx)),
+		newRoute("POST", "/txs/send", signing(h.send)),
+		newRoute("POST", "/txs/multisend", signing(h.multiSend)),
+		newRoute("POST", "/txs/orders", signing(h.newOrders)),
+		newRoute("POST", "/txs/cancel", signing(h.cancelOrders)),
+	}
+}
+
+func signingDisabled(w http.ResponseWriter, r *http.Request, vars map[string]string) {
+	writeError(w, http.StatusNotImplemented, types.ErrUnknownRequest("signing is disabled, the gateway has no keybase"))
+}
+
+// BroadcastReq is the body of POST /txs. The signed tx is given either as the amino JSON of the
+// StdTx, like the output of the signing endpoints, or as its amino encoded bytes in base64.
+type BroadcastReq struct {
+	Tx      json.RawMessage `json:"tx,omitempty"`
+	TxBytes []byte          `json:"tx_bytes,omitempty"`
+	// Mode is block, sync or async. It's block if it's empty.
+	Mode string `json:"mode,omitempty"`
+}
+
+// BaseReq holds the fields of the requests of the signing endpoints. The account number and the
+// sequence are queried from the chain if they're not given.
+type BaseReq struct {
+	Name          string  `json:"name"`
+	Password      string  `json:"password"`
+	Memo          string  `json:"memo,omitempty"`
+	AccountNumber *uint64 `json:"account_number,omitempty"`
+	Sequence      *uint64 `json:"sequence,omitempty"`
+	Mode          string  `json:"mode,omitempty"`
+}
+
+// SignReq is the body of POST /txs/sign, which returns the signed tx without broadcasting it
+type SignReq struct {
+	BaseReq BaseReq         `json:"base_req"`
+	Tx      json.RawMessage `json:"tx"`
+}
+
+// SendReq is the body of POST /txs/send
+type SendReq struct {
+	BaseReq BaseReq `json:"base_req"`
+	To      string  `json:"to"`
+	Amount  string  `json:"amount"`
+}
+
+// MultiSendReq is the body of POST /txs/multisend
+type MultiSendReq struct {
+	BaseReq   BaseReq          `json:"base_req"`
+	Transfers []types.Transfer `json:"transfers"`
+}
+
+// OrderReq is an order of NewOrdersReq
+type OrderReq struct {
+	Product  string `json:"product"`
+	Side     string `json:"side"`
+	Price    string `json:"price"`
+	Quantity string `json:"quantity"`
+}
+
+// NewOrdersReq is the body of POST /txs/orders
+type NewOrdersReq struct {
+	BaseReq BaseReq    `json:"base_req"`
+	Orders  []OrderReq `json:"orders"`
+}
+
+// CancelOrdersReq is the body of POST /txs/cancel
+type CancelOrdersReq struct {
+	BaseReq  BaseReq  `json:"base_req"`
+	OrderIDs []string `json:"order_ids"`
+}
+
+func broadcastMode(mode string) (string, error) {
+	switch mode {
+	case "":
+		return client.BroadcastBlock, nil
+	case client.BroadcastBlock, client.BroadcastSync, client.BroadcastAsync:
+		return mode, nil
+	default:
+		return "", types.ErrUnknownRequest(fmt.Sprintf("unsupported broadcast mode %q, it's block, sync or async", mode))
+	}
+}
+
+func (h *Handler) broadcastTx(w http.ResponseWriter, r *http.Request, vars map[string]string) {
+	var req BroadcastReq
+	if err := decodeBody(r, &req); err != nil {
+		writeError(w, 0, err)
+		return
+	}
+	mode, err := broadcastMode(req.Mode)
+	if err != nil {
+		writeError(w, 0, err)
+		return
+	}
+
+	txBytes := req.TxBytes
+	if len(req.Tx) != 0 {
+		var stdTx tx.StdTx
+		if err := tx.MsgCdc.UnmarshalJSON(req.Tx, &stdTx); err != nil {
+			writeError(w, 0, types.ErrTxDecode(err.Error()))
+			return
+		}
+		if len(stdTx.Signatures) == 0 {
+			writeError(w, 0, types.ErrNoSignatures("the tx isn't signed"))
+			return
+		}
+		if txBytes, err = tx.MsgCdc.MarshalBinaryLengthPrefixed(stdTx); err != nil {
+			writeError(w, 0, types.ErrTxDecode(err.Error()))
+			return
+		}
+	}
+	if len(txBytes) == 0 {
+		writeError(w, 0, types.ErrTxDecode("no tx or tx_bytes given"))
+		return
+	}
+
+	h.writeTxResponse(w, mode, txBytes)
+}
+
+func (h *Handler) writeTxResponse(w http.ResponseWriter, mode string, txBytes []byte) {
+	resp, err := h.cli.BroadcastTx(txBytes, mode)
+	if err != nil {
+		writeError(w, 0, err)
+		return
+	}
+	writeResult(w, resp)
+}
+
+// sign signs a tx of the msgs by the key of the base request
+func (h *Handler) sign(req BaseReq, stdTx tx.StdTx) (tx.StdTx, error) {
+	if req.Name == "" || req.Password == "" {
+		return tx.StdTx{}, types.ErrUnknownRequest("name and password of the signing key are required")
+	}
+	info, err := h.kb.Get(req.Name)
+	if err != nil {
+		return tx.StdTx{}, types.ErrUnknownAddress(err.Error())
+	}
+
+	var accNum, seqNum uint64
+	if req.AccountNumber == nil || req.Sequence == nil {
+		acc, err := h.cli.GetAccountInfoByAddr(h.network().FormatAccAddress(info.GetAddress()))
+		if err != nil {
+			return tx.StdTx{}, err
+		}
+		accNum, seqNum = acc.GetAccountNumber(), acc.GetSequence()
+	}
+	if req.AccountNumber != nil {
+		accNum = *req.AccountNumber
+	}
+	if req.Sequence != nil {
+		seqNum = *req.Sequence
+	}
+
+	signed, err := tx.SignStdTxWithNetwork(h.network(), h.kb, req.Name, req.Password, stdTx, accNum, seqNum)
+	if keyerror.IsErrWrongPassword(err) {
+		return tx.StdTx{}, types.ErrUnauthorized(err.Error())
+	}
+	if err != nil {
+		return tx.StdTx{}, types.ErrInternal(err.Error())
+	}
+	return signed, nil
+}
+
+// signAndBroadcast signs a tx of the msg by the key of the base request and broadcasts it
+func (h *Handler) signAndBroadcast(w http.ResponseWriter, req BaseReq, m types.Msg) {
+	mode, err := broadcastMode(req.Mode)
+	if err != nil {
+		writeError(w, 0, err)
+		return
+	}
+	if err := m.ValidateBasic(); err != nil {
+		writeError(w, 0, err)
+		return
+	}
+
+	signed, err := h.sign(req, tx.BuildUnsignedStdTx(req.Memo, []types.Msg{m}))
+	if err != nil {
+		writeError(w, 0, err)
+		return
+	}
+	txBytes, err := tx.MsgCdc.MarshalBinaryLengthPrefixed(signed)
+	if err != nil {
+		writeError(w, 0, types.ErrInternal(err.Error()))
+		return
+	}
+	h.writeTxResponse(w, mode, txBytes)
+}
+
+// signer returns the address of the key of the base request
+func (h *Handler) signer(req BaseReq) (types.AccAddress, error) {
+	info, err := h.kb.Get(req.Name)
+	if err != nil {
+		return nil, types.ErrUnknownAddress(err.Error())
+	}
+	return info.GetAddress(), nil
+}
+
+func (h *Handler) signTx(w http.ResponseWriter, r *http.Request, vars map[string]string) {
+	var req SignReq
+	if err := decodeBody(r, &req); err != nil {
+		writeError(w, 0, err)
+		return
+	}
+	var stdTx tx.StdTx
+	if err := tx.MsgCdc.UnmarshalJSON(req.Tx, &stdTx); err != nil {
+		writeError(w, 0, types.ErrTxDecode(err.Error()))
+		return
+	}
+
+	signed, err := h.sign(req.BaseReq, stdTx)
+	if err != nil {
+		writeError(w, 0, err)
+		return
+	}
+	bz, err := tx.MsgCdc.MarshalJSON(signed)
+	if err != nil {
+		writeError(w, 0, types.ErrInternal(err.Error()))
+		return
+	}
+	writeJSON(w, http.StatusOK, bz)
+}
+
+func (h *Handler) send(w http.ResponseWriter, r *http.Request, vars map[string]string) {
+	var req SendReq
+	if err := decodeBody(r, &req); err != nil {
+		writeError(w, 0, err)
+		return
+	}
+	from, err := h.signer(req.BaseReq)
+	if err != nil {
+		writeError(w, 0, err)
+		return
+	}
+	to, err := h.network().ParseAccAddress(req.To)
+	if err != nil {
+		writeError(w, 0, types.ErrInvalidAddress(fmt.Sprintf("invalid receiver address %s: %s", req.To, err)))
+		return
+	}
+	coins, err := utils.ParseCoins(req.Amount)
+	if err != nil {
+		writeError(w, 0, types.ErrInvalidCoins(err.Error()))
+		return
+	}
+
+	h.signAndBroadcast(w, req.BaseReq, msg.NewMsgTokenSend(from, to, coins))
+}
+
+func (h *Handler) multiSend(w http.ResponseWriter, r *http.Request, vars map[string]string) {
+	var req MultiSendReq
+	if err := decodeBody(r, &req); err != nil {
+		writeError(w, 0, err)
+		return
+	}
+	from, err := h.signer(req.BaseReq)
+	if err != nil {
+		writeError(w, 0, err)
+		return
+	}
+	if len(req.Transfers) == 0 {
+		writeError(w, 0, types.ErrUnknownRequest("no transfers given"))
+		return
+	}
+
+	transfers := make([]types.TransferUnit, len(req.Transfers))
+	for i, transfer := range req.Transfers {
+		to, err := h.network().ParseAccAddress(transfer.To)
+		if err != nil {
+			writeError(w, 0, types.ErrInvalidAddress(fmt.Sprintf("invalid receiver address %s: %s", transfer.To, err)))
+			return
+		}
+		coins, err := utils.ParseCoins(transfer.Amount)
+		if err != nil || len(coins) == 0 {
+			writeError(w, 0, types.ErrInvalidCoins(fmt.Sprintf("invalid amount %q to transfer to %s", transfer.Amount, transfer.To)))
+			return
+		}
+		transfers[i] = types.TransferUnit{To: to, Coins: coins}
+	}
+
+	h.signAndBroadcast(w, req.BaseReq, msg.NewMsgMultiSend(from, transfers))
+}
+
+func (h *Handler) newOrders(w http.ResponseWriter, r *http.Request, vars map[string]string) {
+	var req NewOrdersReq
+	if err := decodeBody(r, &req); err != nil {
+		writeError(w, 0, err)
+		return
+	}
+	from, err := h.signer(req.BaseReq)
+	if err != nil {
+		writeError(w, 0, err)
+		return
+	}
+	if len(req.Orders) == 0 {
+		writeError(w, 0, types.ErrUnknownRequest("no orders given"))
+		return
+	}
+
+	items := make([]msg.OrderItem, len(req.Orders))
+	for i, order := range req.Orders {
+		if _, err := types.NewDecFromStr(order.Price); err != nil {
+			writeError(w, 0, types.ErrUnknownRequest(fmt.Sprintf("invalid price %q: %s", order.Price, err)))
+			return
+		}
+		if _, err := types.NewDecFromStr(order.Quantity); err != nil {
+			writeError(w, 0, types.ErrUnknownRequest(fmt.Sprintf("invalid quantity %q: %s", order.Quantity, err)))
+			return
+		}
+		items[i] = msg.NewOrderItem(order.Product, order.Side, order.Price, order.Quantity)
+	}
+	// the orders breaking the rules of their products are bad requests, the failures to query the
+	// products keep their status
+	items, err = h.cli.ValidateOrderItems(items)
+	if err != nil {
+		writeError(w, 0, err)
+		return
+	}
+
+	h.signAndBroadcast(w, req.BaseReq, msg.NewMsgNewOrders(from, items))
+}
+
+func (h *Handler) cancelOrders(w http.ResponseWriter, r *http.Request, vars map[string]string) {
+	var req CancelOrdersReq
+	if err := decodeBody(r, &req); err != nil {
+		writeError(w, 0, err)
+		return
+	}
+	from, err := h.signer(req.BaseReq)
+	if err != nil {
+		writeError(w, 0, err)
+		return
+	}
+
+	h.signAndBroadcast(w, req.BaseReq, msg.NewMsgCancelOrders(from, req.OrderIDs))
+}
@@ -0,0 +1,341 @@
+// Package rpcreplay records the JSON-RPC traffic between an OKChainClient and a node into a fixture
+// file and replays it without any network, so that the tests of the queries can run offline and
+// still decode real responses.
+//
+// Record the fixture once against a node:
+//
+//	recorder := rpcreplay.NewRecorder(rpcUrl)
+//	cli := client.NewClientWithHTTPClient(rpcUrl, recorder.HTTPClient())
+//	// ... invoke the client
+//	err := recorder.Save("testdata/backend.json")
+//
+// And replay it later:
+//
+//	replayer, err := rpcreplay.NewReplayer("testdata/backend.json")
+//	cli := client.NewClientWithHTTPClient(rpcUrl, replayer.HTTPClient())
+package rpcreplay
+
+import (
+	"bytes"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"sync"
+
+	rpcclient "github.com/tendermint/tendermint/rpc/lib/client"
+)
+
+// Fixture is the content of a fixture file
+type Fixture struct {
+	Interactions []Interaction `json:"interactions"`
+}
+
+// Interaction is a recorded JSON-RPC call. The id of the request isn't kept because it's random
+// for every client.
+type Interaction struct {
+	Method string          `json:"method"`
+	Params json.RawMessage `json:"params,omitempty"`
+	Result json.RawMessage `json:"result,omitempty"`
+	Error  json.RawMessage `json:"error,omitempty"`
+}
+
+// ErrNotRecorded is returned by the replayer if no recorded interaction matches a request
+var ErrNotRecorded = errors.New("rpcreplay: no recorded response")
+
+type rpcRequest struct {
+	JSONRPC string          `json:"jsonrpc"`
+	ID      json.RawMessage `json:"id"`
+	Method  string          `json:"method"`
+	Params  json.RawMessage `json:"params"`
+}
+
+type rpcResponse struct {
+	JSONRPC string          `json:"jsonrpc"`
+	ID      json.RawMessage `json:"id"`
+	Result  json.RawMessage `json:"result,omitempty"`
+	Error   json.RawMessage `json:"error,omitempty"`
+}
+
+//----------------------------------------
+// record
+
+// Recorder is a http.RoundTripper which sends the requests to the node and records them along
+// with the responses
+type Recorder struct {
+	base http.RoundTripper
+
+	mtx          sync.Mutex
+	interactions []Interaction
+}
+
+var _ http.RoundTripper = (*Recorder)(nil)
+
+// NewRecorder creates a recorder sending the requests to the node at the given rpc address
+func NewRecorder(remote string) *Recorder {
+	return NewRecorderWithTransport(rpcclient.DefaultHTTPClient(remote).Transport)
+}
+
+// NewRecorderWithTransport creates a recorder sending the requests through the given transport
+func NewRecorderWithTransport(base http.RoundTripper) *Recorder {
+	return &Recorder{base: base}
+}
+
+// HTTPClient returns a http client using the recorder as its transport
+func (r *Recorder) HTTPClient() *http.Client {
+	return &http.Client{Transport: r}
+}
+
+// RoundTrip implements the http.RoundTripper interface
+func (r *Recorder) RoundTrip(req *http.Request) (*http.Response, error) {
+	reqBody, err := readBody(&req.Body)
+	if err != nil {
+		return nil, err
+	}
+	var rpcReq rpcRequest
+	if err := json.Unmarshal(reqBody, &rpcReq); err != nil {
+		return nil, fmt.Errorf("rpcreplay: only single JSON-RPC requests can be recorded: %s", err)
+	}
+
+	resp, err := r.base.RoundTrip(req)
+	if err != nil {
+		return nil, err
+	}
+	respBody, err := readBody(&resp.Body)
+	if err != nil {
+		return nil, err
+	}
+	var rpcResp rpcResponse
+	if err := json.Unmarshal(respBody, &rpcResp); err != nil {
+		return nil, fmt.Errorf("rpcreplay: invalid JSON-RPC response of %s: %s", rpcReq.Method, err)
+	}
+
+	r.mtx.Lock()
+	defer r.mtx.Unlock()
+	r.interactions = append(r.interactions, Interaction{
+		Method: rpcReq.Method,
+		Params: canonicalJSON(rpcReq.Params),
+		Result: rpcResp.Result,
+		Error:  rpcResp.Error,
+	})
+	return resp, nil
+}
+
+// Fixture returns the interactions recorded so far
+func (r *Recorder) Fixture() Fixture {
+	r.mtx.Lock()
+	defer r.mtx.Unlock()
+	return Fixture{Interactions: append([]Interaction(nil), r.interactions...)}
+}
+
+// Save writes the interactions recorded so far to the fixture file
+func (r *Recorder) Save(path string) error {
+	bz, err := json.MarshalIndent(r.Fixture(), "", "  ")
+	if err != nil {
+		return err
+	}
+	return ioutil.WriteFile(path, append(bz, '\n'), 0644)
+}
+
+//----------------------------------------
+// replay
+
+// Replayer is a http.RoundTripper which answers the requests with the recorded responses. Every
+// recorded interaction is served once. A request is matched to the first unused interaction with
+// the same method and params, and fails with ErrNotRecorded if there's none.
+type Replayer struct {
+	mtx          sync.Mutex
+	interactions []Interaction
+	used         []bool
+	ignored      map[string]bool
+}
+
+var _ http.RoundTripper = (*Replayer)(nil)
+
+// NewReplayer loads the fixture file to replay
+func NewReplayer(path string) (*Replayer, error) {
+	bz, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var fixture Fixture
+	if err := json.Unmarshal(bz, &fixture); err != nil {
+		return nil, fmt.Errorf("rpcreplay: invalid fixture %s: %s", path, err)
+	}
+	return NewReplayerFromFixture(fixture), nil
+}
+
+// NewReplayerFromFixture creates a replayer serving the interactions of the fixture
+func NewReplayerFromFixture(fixture Fixture) *Replayer {
+	interactions := make([]Interaction, len(fixture.Interactions))
+	for i, interaction := range fixture.Interactions {
+		// the params are indented in the fixture files
+		interaction.Params = canonicalJSON(interaction.Params)
+		interactions[i] = interaction
+	}
+	return &Replayer{
+		interactions: interactions,
+		used:         make([]bool, len(interactions)),
+	}
+}
+
+// HTTPClient returns a http client using the replayer as its transport
+func (r *Replayer) HTTPClient() *http.Client {
+	return &http.Client{Transport: r}
+}
+
+// IgnoreQueryFields sets the fields of the query data of an abci_query which aren't compared, e.g.
+// the end time of a query which is derived from the current time. The other fields, the query
+// path and the other params still have to match.
+func (r *Replayer) IgnoreQueryFields(fields ...string) {
+	r.mtx.Lock()
+	defer r.mtx.Unlock()
+	r.ignored = make(map[string]bool, len(fields))
+	for _, field := range fields {
+		r.ignored[field] = true
+	}
+}
+
+// Remaining returns the number of the recorded interactions which haven't been served
+func (r *Replayer) Remaining() int {
+	r.mtx.Lock()
+	defer r.mtx.Unlock()
+	remaining := 0
+	for _, used := range r.used {
+		if !used {
+			remaining++
+		}
+	}
+	return remaining
+}
+
+// RoundTrip implements the http.RoundTripper interface
+func (r *Replayer) RoundTrip(req *http.Request) (*http.Response, error) {
+	reqBody, err := readBody(&req.Body)
+	if err != nil {
+		return nil, err
+	}
+	var rpcReq rpcRequest
+	if err := json.Unmarshal(reqBody, &rpcReq); err != nil {
+		return nil, fmt.Errorf("rpcreplay: only single JSON-RPC requests can be replayed: %s", err)
+	}
+
+	interaction, err := r.match(rpcReq.Method, canonicalJSON(rpcReq.Params))
+	if err != nil {
+		return nil, err
+	}
+
+	respBody, err := json.Marshal(rpcResponse{
+		JSONRPC: "2.0",
+		ID:      rpcReq.ID,
+		Result:  interaction.Result,
+		Error:   interaction.Error,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &http.Response{
+		Status:        "200 OK",
+		StatusCode:    http.StatusOK,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        http.Header{"Content-Type": []string{"application/json"}},
+		Body:          ioutil.NopCloser(bytes.NewReader(respBody)),
+		ContentLength: int64(len(respBody)),
+		Request:       req,
+	}, nil
+}
+
+func (r *Replayer) match(method string, params json.RawMessage) (Interaction, error) {
+	r.mtx.Lock()
+	defer r.mtx.Unlock()
+
+	params = r.withoutIgnored(params)
+	for i, interaction := range r.interactions {
+		if r.used[i] || interaction.Method != method {
+			continue
+		}
+		if bytes.Equal(r.withoutIgnored(interaction.Params), params) {
+			r.used[i] = true
+			return interaction, nil
+		}
+	}
+	return Interaction{}, fmt.Errorf("%w for %s %s", ErrNotRecorded, method, params)
+}
+
+// withoutIgnored removes the ignored fields from the query data of the abci_query params. The
+// params are kept as they are if no field is ignored or there's no JSON object in the data.
+func (r *Replayer) withoutIgnored(params json.RawMessage) json.RawMessage {
+	if len(r.ignored) == 0 {
+		return params
+	}
+	var p map[string]interface{}
+	if err := json.Unmarshal(params, &p); err != nil {
+		return params
+	}
+	data, ok := p["data"].(string)
+	if !ok {
+		return params
+	}
+	bz, err := hex.DecodeString(data)
+	if err != nil {
+		return params
+	}
+	var query map[string]interface{}
+	if err := json.Unmarshal(bz, &query); err != nil {
+		return params
+	}
+	for field := range r.ignored {
+		delete(query, field)
+	}
+	p["data"] = query
+	return canonicalJSON(mustMarshal(p))
+}
+
+//----------------------------------------
+// helpers
+
+// readBody reads the body and replaces it with a copy, so that it can be read again
+func readBody(body *io.ReadCloser) ([]byte, error) {
+	if *body == nil {
+		return nil, nil
+	}
+	bz, err := ioutil.ReadAll(*body)
+	if err != nil {
+		return nil, err
+	}
+	if err := (*body).Close(); err != nil {
+		return nil, err
+	}
+	*body = ioutil.NopCloser(bytes.NewReader(bz))
+	return bz, nil
+}
+
+// canonicalJSON sorts the keys of the JSON objects, so that the same params are always encoded
+// into the same bytes
+func canonicalJSON(bz json.RawMessage) json.RawMessage {
+	if len(bz) == 0 {
+		return nil
+	}
+	var v interface{}
+	if err := json.Unmarshal(bz, &v); err != nil {
+		return bz
+	}
+	canonical, err := json.Marshal(v)
+	if err != nil {
+		return bz
+	}
+	return canonical
+}
+
+func mustMarshal(v interface{}) json.RawMessage {
+	bz, err := json.Marshal(v)
+	if err != nil {
+		panic(err)
+	}
+	return bz
+}
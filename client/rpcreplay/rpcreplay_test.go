@@ -0,0 +1,101 @@
+package rpcreplay_test
+
+import (
+	"errors"
+	"io/ioutil"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/okex/okchain-go-sdk/client"
+	"github.com/okex/okchain-go-sdk/client/fakenode"
+	"github.com/okex/okchain-go-sdk/client/rpcreplay"
+	"github.com/okex/okchain-go-sdk/types"
+)
+
+const addr = "okchain1g7c3nvac7mjgn2m9mqllgat8wwd3aptdqket5k"
+
+type backendResults struct {
+	candles [][]string
+	deals   []types.Deal
+	tokens  []types.Token
+}
+
+func queryBackend(t *testing.T, cli client.OKChainClient, end int) backendResults {
+	var res backendResults
+	var err error
+	if res.candles, err = cli.GetCandlesInfo("xxb_okt", 60, 100); err != nil {
+		t.Fatal(err)
+	}
+	if res.deals, err = cli.GetDealsInfo(addr, "xxb_okt", "BUY", 0, end, 1, 10); err != nil {
+		t.Fatal(err)
+	}
+	if res.tokens, err = cli.GetTokensInfo(); err != nil {
+		t.Fatal(err)
+	}
+	if _, err = cli.GetTokenInfo("unknown"); !types.IsErrUnknownRequest(err) {
+		t.Fatalf("unexpected error of an unknown token: %v", err)
+	}
+	return res
+}
+
+func TestRecordAndReplay(t *testing.T) {
+	dir, err := ioutil.TempDir("", "rpcreplay")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	fixturePath := filepath.Join(dir, "backend.json")
+
+	// record against a node
+	node := fakenode.New()
+	rpcUrl := node.Addr()
+	accAddr, _ := types.AccAddressFromBech32(addr)
+	node.AddToken(types.Token{Symbol: "okt", TotalSupply: types.NewDec(1000), Owner: accAddr})
+	node.SetCandles([][]string{{"1572566400", "1.0000", "1.2000", "0.9000", "1.1000", "100.0000"}})
+	node.AddDeals(types.Deal{Timestamp: time.Now().Unix(), BlockHeight: 1, OrderId: "ID0000000001-1", Sender: addr, Product: "xxb_okt", Side: "BUY", Price: 1.1, Quantity: 10})
+
+	recorder := rpcreplay.NewRecorder(rpcUrl)
+	recorded := queryBackend(t, client.NewClientWithHTTPClient(rpcUrl, recorder.HTTPClient()), int(time.Now().Unix()))
+	node.Close()
+	if err := recorder.Save(fixturePath); err != nil {
+		t.Fatal(err)
+	}
+
+	// replay with the node closed, the deals are queried with another end time which has to be
+	// ignored explicitly
+	replayer, err := rpcreplay.NewReplayer(fixturePath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	cli := client.NewClientWithHTTPClient(rpcUrl, replayer.HTTPClient())
+	end := int(time.Now().Unix()) + 1
+	if _, err := cli.GetDealsInfo(addr, "xxb_okt", "BUY", 0, end, 1, 10); err == nil || !strings.Contains(err.Error(), rpcreplay.ErrNotRecorded.Error()) {
+		t.Fatalf("expected ErrNotRecorded of another end time but got %v", err)
+	}
+	replayer.IgnoreQueryFields("End")
+	// the other fields still have to match
+	if _, err := cli.GetDealsInfo(addr, "xxb_okb", "BUY", 0, end, 1, 10); err == nil || !strings.Contains(err.Error(), rpcreplay.ErrNotRecorded.Error()) {
+		t.Fatalf("expected ErrNotRecorded of another product but got %v", err)
+	}
+	replayed := queryBackend(t, cli, end)
+	if !reflect.DeepEqual(recorded, replayed) {
+		t.Fatalf("replayed results %v differ from the recorded ones %v", replayed, recorded)
+	}
+	if replayer.Remaining() != 0 {
+		t.Fatalf("%d interactions not replayed", replayer.Remaining())
+	}
+
+	// every interaction is served once. The tendermint client wraps the errors of the transport
+	// without Unwrap, so only the message is kept.
+	if _, err := cli.GetTokensInfo(); err == nil || !strings.Contains(err.Error(), rpcreplay.ErrNotRecorded.Error()) {
+		t.Fatalf("expected ErrNotRecorded but got %v", err)
+	}
+	if _, err := replayer.RoundTrip(httptest.NewRequest("POST", "/", strings.NewReader(`{"method":"status","params":{}}`))); !errors.Is(err, rpcreplay.ErrNotRecorded) {
+		t.Fatalf("expected ErrNotRecorded but got %v", err)
+	}
+}
@@ -2,13 +2,13 @@ package client
 
 import (
 	"fmt"
-	"github.com/okex/okchain-go-sdk/common/libs/pkg/errors"
 	"github.com/okex/okchain-go-sdk/common/transactParams"
 	"github.com/okex/okchain-go-sdk/crypto/keys"
 	"github.com/okex/okchain-go-sdk/types"
 	"github.com/okex/okchain-go-sdk/types/msg"
 	"github.com/okex/okchain-go-sdk/types/tx"
 	"github.com/okex/okchain-go-sdk/utils"
+	"time"
 )
 
 // broadcast mode
@@ -19,11 +19,12 @@ const (
 )
 
 func (cli *OKChainClient) Send(fromInfo keys.Info, passWd, toAddr, coinsStr, memo string, accNum, seqNum uint64) (resp types.TxResponse, err error) {
-	if !transactParams.IsValidSendParams(fromInfo, passWd, toAddr) {
-		return types.TxResponse{}, errors.New("err : params input to send are invalid")
+	defer cli.metrics.measure("Send", time.Now(), &err)
+	if err := transactParams.CheckSendParamsWithPrefix(fromInfo, passWd, toAddr, cli.network.Bech32Prefixes.AccountAddr); err != nil {
+		return types.TxResponse{}, fmt.Errorf("err : params input to send are invalid: %s", err)
 	}
 
-	to, err := types.AccAddressFromBech32(toAddr)
+	to, err := cli.network.ParseAccAddress(toAddr)
 	if err != nil {
 		return types.TxResponse{}, fmt.Errorf("err : parse Address [%s] error: %s", toAddr, err)
 	}
@@ -33,9 +34,32 @@ func (cli *OKChainClient) Send(fromInfo keys.Info, passWd, toAddr, coinsStr, mem
 		return types.TxResponse{}, fmt.Errorf("err : parse Coins [%s] error: %s", coinsStr, err)
 	}
 
+	release, err := cli.reserveFunds(cli.network.FormatAccAddress(fromInfo.GetAddress()), coins.ToDecCoins())
+	if err != nil {
+		return types.TxResponse{}, fmt.Errorf("err : funds to send are unavailable: %w", err)
+	}
+	defer release()
+
 	msg := msg.NewMsgTokenSend(fromInfo.GetAddress(), to, coins)
 
-	stdBytes, err := tx.BuildAndSignAndEncodeStdTx(fromInfo.GetName(), passWd, memo, []types.Msg{msg}, accNum, seqNum)
+	stdBytes, err := tx.BuildAndSignAndEncodeStdTxWithNetwork(cli.network, fromInfo.GetName(), passWd, memo, []types.Msg{msg}, accNum, seqNum)
+	if err != nil {
+		return types.TxResponse{}, fmt.Errorf("err : build and sign stdTx error: %s", err.Error())
+	}
+
+	return cli.broadcast(stdBytes, BroadcastBlock)
+}
+
+// MultiSend transfers coins to several receivers in a single tx
+func (cli *OKChainClient) MultiSend(fromInfo keys.Info, passWd string, transfers []types.TransferUnit, memo string, accNum, seqNum uint64) (resp types.TxResponse, err error) {
+	defer cli.metrics.measure("MultiSend", time.Now(), &err)
+	if err := transactParams.CheckMultiSendParams(fromInfo, passWd, transfers); err != nil {
+		return types.TxResponse{}, fmt.Errorf("err : params input to multi-send are invalid: %s", err)
+	}
+
+	msg := msg.NewMsgMultiSend(fromInfo.GetAddress(), transfers)
+
+	stdBytes, err := tx.BuildAndSignAndEncodeStdTxWithNetwork(cli.network, fromInfo.GetName(), passWd, memo, []types.Msg{msg}, accNum, seqNum)
 	if err != nil {
 		return types.TxResponse{}, fmt.Errorf("err : build and sign stdTx error: %s", err.Error())
 	}
@@ -43,48 +67,89 @@ func (cli *OKChainClient) Send(fromInfo keys.Info, passWd, toAddr, coinsStr, mem
 	return cli.broadcast(stdBytes, BroadcastBlock)
 }
 
-func (cli *OKChainClient) NewOrder(fromInfo keys.Info, passWd, product, side, price, quantity, memo string, accNum, seqNum uint64) (types.TxResponse, error) {
+func (cli *OKChainClient) NewOrder(fromInfo keys.Info, passWd, product, side, price, quantity, memo string, accNum, seqNum uint64) (resp types.TxResponse, err error) {
+	defer cli.metrics.measure("NewOrder", time.Now(), &err)
 	items := []msg.OrderItem{
 		msg.NewOrderItem(product, side, price, quantity),
 	}
 	return cli.NewOrders(fromInfo, items, passWd, memo, accNum, seqNum)
 }
 
-func (cli *OKChainClient) CancelOrder(fromInfo keys.Info, passWd, orderId, memo string, accNum, seqNum uint64) (types.TxResponse, error) {
+func (cli *OKChainClient) CancelOrder(fromInfo keys.Info, passWd, orderId, memo string, accNum, seqNum uint64) (resp types.TxResponse, err error) {
+	defer cli.metrics.measure("CancelOrder", time.Now(), &err)
 	orderIdList := []string {
 		orderId,
 	}
 	return cli.CancelOrders(fromInfo, passWd, memo, orderIdList, accNum, seqNum)
 }
 
-func (cli *OKChainClient) NewOrders(fromInfo keys.Info, orderItems []msg.OrderItem, passWd, memo string, accNum, seqNum uint64) (types.TxResponse, error) {
+func (cli *OKChainClient) NewOrders(fromInfo keys.Info, orderItems []msg.OrderItem, passWd, memo string, accNum, seqNum uint64) (resp types.TxResponse, err error) {
+	defer cli.metrics.measure("NewOrders", time.Now(), &err)
+	resp, release, err := cli.newOrders(fromInfo, orderItems, passWd, memo, accNum, seqNum, BroadcastBlock)
+	release()
+	return resp, err
+}
+
+// newOrders signs and broadcasts the orders in the broadcast mode. The funds of the orders are
+// reserved until the returned release is called, which is never nil.
+func (cli *OKChainClient) newOrders(fromInfo keys.Info, orderItems []msg.OrderItem, passWd, memo string, accNum, seqNum uint64, broadcastMode string) (resp types.TxResponse, release func(), err error) {
+	release = func() {}
 	for _, item := range orderItems {
-		if !transactParams.IsValidNewOrderParams(fromInfo, passWd, item.Product, item.Side) {
-			return types.TxResponse{}, errors.New("err : params input to pend a order are invalid")
+		if err := transactParams.CheckNewOrderParams(fromInfo, passWd, item.Product, item.Side); err != nil {
+			return types.TxResponse{}, release, fmt.Errorf("err : params input to pend a order are invalid: %s", err)
 		}
 	}
+	orderItems, err = cli.ValidateOrderItems(orderItems)
+	if err != nil {
+		return types.TxResponse{}, release, fmt.Errorf("err : orders don't fit their products: %s", err)
+	}
+	if cli.balanceCheck {
+		required, err := cli.RequiredFundsOfOrders(orderItems)
+		if err != nil {
+			return types.TxResponse{}, release, fmt.Errorf("err : funds of the orders are unknown: %s", err)
+		}
+		reserved, err := cli.reserveFunds(cli.network.FormatAccAddress(fromInfo.GetAddress()), required)
+		if err != nil {
+			return types.TxResponse{}, release, fmt.Errorf("err : funds of the orders are unavailable: %w", err)
+		}
+		release = reserved
+	}
 
 	msg := msg.NewMsgNewOrders(fromInfo.GetAddress(), orderItems)
 
-	stdBytes, err := tx.BuildAndSignAndEncodeStdTx(fromInfo.GetName(), passWd, memo, []types.Msg{msg}, accNum, seqNum)
+	stdBytes, err := tx.BuildAndSignAndEncodeStdTxWithNetwork(cli.network, fromInfo.GetName(), passWd, memo, []types.Msg{msg}, accNum, seqNum)
 	if err != nil {
-		return types.TxResponse{}, fmt.Errorf("err : build and sign stdTx error: %s", err.Error())
+		return types.TxResponse{}, release, fmt.Errorf("err : build and sign stdTx error: %s", err.Error())
 	}
 
-	return cli.broadcast(stdBytes, BroadcastBlock)
+	resp, err = cli.broadcast(stdBytes, broadcastMode)
+	return resp, release, err
+}
 
+func (cli *OKChainClient) CancelOrders(fromInfo keys.Info, passWd, memo string, orderIdList []string, accNum, seqNum uint64) (resp types.TxResponse, err error) {
+	defer cli.metrics.measure("CancelOrders", time.Now(), &err)
+	return cli.cancelOrders(fromInfo, passWd, memo, orderIdList, accNum, seqNum, BroadcastBlock)
 }
 
-func (cli *OKChainClient) CancelOrders(fromInfo keys.Info, passWd, memo string, orderIdList []string, accNum, seqNum uint64) (types.TxResponse, error) {
-	if !transactParams.IsValidCancelOrderParams(fromInfo, passWd) {
-		return types.TxResponse{}, errors.New("err : params input to cancel a order are invalid")
+func (cli *OKChainClient) cancelOrders(fromInfo keys.Info, passWd, memo string, orderIdList []string, accNum, seqNum uint64, broadcastMode string) (resp types.TxResponse, err error) {
+	if err := transactParams.CheckCancelOrderParams(fromInfo, passWd); err != nil {
+		return types.TxResponse{}, fmt.Errorf("err : params input to cancel a order are invalid: %s", err)
 	}
 
 	msg := msg.NewMsgCancelOrders(fromInfo.GetAddress(), orderIdList)
-	stdBytes, err := tx.BuildAndSignAndEncodeStdTx(fromInfo.GetName(), passWd, memo, []types.Msg{msg}, accNum, seqNum)
+	stdBytes, err := tx.BuildAndSignAndEncodeStdTxWithNetwork(cli.network, fromInfo.GetName(), passWd, memo, []types.Msg{msg}, accNum, seqNum)
 	if err != nil {
 		return types.TxResponse{}, fmt.Errorf("err : build and sign stdTx error: %s", err.Error())
 	}
 
-	return cli.broadcast(stdBytes, BroadcastBlock)
+	return cli.broadcast(stdBytes, broadcastMode)
+}
+
+// BroadcastTx broadcasts an amino encoded tx which has been signed already, e.g. offline by tx.SignStdTx
+func (cli *OKChainClient) BroadcastTx(txBytes []byte, broadcastMode string) (resp types.TxResponse, err error) {
+	defer cli.metrics.measure("BroadcastTx", time.Now(), &err)
+	if len(txBytes) == 0 {
+		return types.TxResponse{}, fmt.Errorf("err : no tx bytes to broadcast")
+	}
+	return cli.broadcast(txBytes, broadcastMode)
 }
@@ -1,7 +1,9 @@
 package client
 
 import (
+	"errors"
 	"fmt"
+	"github.com/okex/okchain-go-sdk/types"
 	"github.com/okex/okchain-go-sdk/utils"
 
 	"testing"
@@ -73,3 +75,24 @@ func TestNewOrdersAndCancelOrders(t *testing.T) {
 	assertNotEqual(t, err, nil)
 	fmt.Println(res)
 }
+
+func TestTxErrors(t *testing.T) {
+	cli := NewClient(rpcUrl)
+	fromInfo, _, err := utils.CreateAccountWithMnemo(mnemonic, name, passWd)
+	assertNotEqual(t, err, nil)
+	accInfo, err := cli.GetAccountInfoByAddr(fromInfo.GetAddress().String())
+	assertNotEqual(t, err, nil)
+
+	// rejected by the ante handler, the sequence isn't consumed
+	_, err = cli.Send(fromInfo, passWd, addr1, "1okt", "my memo", accInfo.GetAccountNumber(), accInfo.GetSequence()+1)
+	assertNotEqual(t, types.IsErrInvalidSequence(err), true)
+
+	// rejected by the handler, the sequence is consumed
+	_, err = cli.Send(fromInfo, passWd, addr1, "100000000okt", "my memo", accInfo.GetAccountNumber(), accInfo.GetSequence())
+	var txErr *types.TxError
+	assertNotEqual(t, errors.As(err, &txErr), true)
+	assertNotEqual(t, types.IsErrInsufficientFunds(err), true)
+
+	_, err = cli.CancelOrder(fromInfo, passWd, "ID0000000001-1", "my memo", accInfo.GetAccountNumber(), accInfo.GetSequence()+1)
+	assertNotEqual(t, types.IsErrOrderNotFound(err), true)
+}
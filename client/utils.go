@@ -1,9 +1,12 @@
 package client
 
 import (
+	"encoding/json"
 	"errors"
+	"fmt"
 	"github.com/okex/okchain-go-sdk/common"
 	"github.com/okex/okchain-go-sdk/types"
+	"github.com/okex/okchain-go-sdk/types/msg"
 )
 
 const (
@@ -35,9 +38,9 @@ func checkParamsGetRecentTxRecord(product string, start, end, page, perPage int)
 	return
 }
 
-func checkParamsGetOpenClosedOrders(addr, product, side string, start, end, page, perPage int) (perPageRet int, err error) {
-	if !common.IsValidAccaddr(addr) {
-		return 0, errors.New("invalid address input")
+func checkParamsGetOpenClosedOrders(addrPrefix, addr, product, side string, start, end, page, perPage int) (perPageRet int, err error) {
+	if err := common.CheckAccAddrWithPrefix(addr, addrPrefix); err != nil {
+		return 0, err
 	}
 
 	if product == "" {
@@ -54,13 +57,13 @@ func checkParamsGetOpenClosedOrders(addr, product, side string, start, end, page
 
 }
 
-func checkParamsGetDealsInfo(addr, product, side string, start, end, page, perPage int) (perPageRet int, err error) {
-	return checkParamsGetOpenClosedOrders(addr, product, side, start, end, page, perPage)
+func checkParamsGetDealsInfo(addrPrefix, addr, product, side string, start, end, page, perPage int) (perPageRet int, err error) {
+	return checkParamsGetOpenClosedOrders(addrPrefix, addr, product, side, start, end, page, perPage)
 }
 
-func checkParamsGetTransactionsInfo(addr string, type_, start, end, page, perPage int) (perPageRet int, err error) {
-	if !common.IsValidAccaddr(addr) {
-		return 0, errors.New("invalid address input")
+func checkParamsGetTransactionsInfo(addrPrefix, addr string, type_, start, end, page, perPage int) (perPageRet int, err error) {
+	if err := common.CheckAccAddrWithPrefix(addr, addrPrefix); err != nil {
+		return 0, err
 	}
 
 	if type_ < 0 {
@@ -78,10 +81,156 @@ func GetOrderIdListFromResponse(result *types.TxResponse) []string {
 		event := result.Events[i]
 		for j := 0 ; j < len(event.Attributes) ; j++ {
 			attribute := event.Attributes[j]
-			if attribute.Key == "orderId" {
+			if attribute.Key == types.AttributeKeyOrderID {
 				orderIdList= append(orderIdList, attribute.Value)
 			}
 		}
 	}
 	return orderIdList
 }
+
+// OrderResultStatus is the status of an order item of a NewOrders tx
+type OrderResultStatus int
+
+const (
+	// OrderUnknown is the status of an order whose result isn't in the response, e.g. of a tx
+	// which isn't committed yet
+	OrderUnknown OrderResultStatus = iota
+	// OrderAccepted is the status of an order placed by the chain
+	OrderAccepted
+	// OrderRejected is the status of an order rejected by the chain
+	OrderRejected
+)
+
+func (status OrderResultStatus) String() string {
+	switch status {
+	case OrderAccepted:
+		return "accepted"
+	case OrderRejected:
+		return "rejected"
+	default:
+		return "unknown"
+	}
+}
+
+// OrderResult is the result of an order item of a NewOrders tx
+type OrderResult struct {
+	// Index is the index of the item in the input
+	Index int
+	// OrderID is the id assigned to the order by the chain. It's empty unless the order is
+	// accepted.
+	OrderID string
+	Status  OrderResultStatus
+	// Code and Message are the code and the reason of a rejected order
+	Code    uint32
+	Message string
+}
+
+// GetOrderResultsFromResponse maps the response of a NewOrders tx back to its order items by the
+// results of the items the order module emits in the events of the message logs. All items are
+// rejected with the error of the tx if it failed. The items whose results aren't in the response
+// are unknown, e.g. of a tx which isn't committed yet. If the response only carries the order
+// ids, the orders are accepted only if there's an id for every item.
+func GetOrderResultsFromResponse(result *types.TxResponse, orderItems []msg.OrderItem) []OrderResult {
+	results := make([]OrderResult, len(orderItems))
+	for i := range results {
+		results[i].Index = i
+	}
+
+	if result.Code != 0 {
+		message := fmt.Sprint(types.ErrFromABCI(result.Codespace, result.Code, result.RawLog).Data())
+		for i := range results {
+			results[i].Status, results[i].Code, results[i].Message = OrderRejected, result.Code, message
+		}
+		return results
+	}
+
+	events := result.Events
+	if len(result.Logs) > 0 {
+		events = nil
+		for _, msgLog := range result.Logs {
+			if msgLog.Success {
+				events = append(events, msgLog.Events...)
+			}
+		}
+	}
+
+	itemResults, err := orderItemResultsFromEvents(events)
+	if err != nil {
+		for i := range results {
+			results[i].Message = err.Error()
+		}
+		return results
+	}
+	if itemResults == nil {
+		// the results of the items are missing, so the ids can only be mapped if none is rejected
+		if orderIdList := orderIdListFromEvents(events); len(orderIdList) == len(orderItems) {
+			itemResults = make([]types.OrderItemResult, len(orderIdList))
+			for i, orderID := range orderIdList {
+				itemResults[i].OrderID = orderID
+			}
+		}
+	}
+
+	for i := range results {
+		switch {
+		case i >= len(itemResults):
+			results[i].Message = "no result of the order in the response"
+		case itemResults[i].Code != 0:
+			results[i].Status, results[i].Code, results[i].Message = OrderRejected, itemResults[i].Code, itemResults[i].Message
+		case itemResults[i].OrderID == "":
+			results[i].Message = "no order id of the order in the response"
+		default:
+			results[i].Status, results[i].OrderID = OrderAccepted, itemResults[i].OrderID
+		}
+	}
+	return results
+}
+
+// GetOrderIdFromResponse returns the id of the first order placed by the tx of the response, or
+// an empty string if there's none
+func GetOrderIdFromResponse(result *types.TxResponse) string {
+	if result.Code != 0 {
+		return ""
+	}
+	if orderIdList := GetOrderIdListFromResponse(result); len(orderIdList) > 0 {
+		return orderIdList[0]
+	}
+	for _, msgLog := range result.Logs {
+		if orderIdList := orderIdListFromEvents(msgLog.Events); msgLog.Success && len(orderIdList) > 0 {
+			return orderIdList[0]
+		}
+	}
+	return ""
+}
+
+// orderItemResultsFromEvents returns the results of the order items in the events, which are nil
+// if there's none
+func orderItemResultsFromEvents(events types.StringEvents) ([]types.OrderItemResult, error) {
+	var itemResults []types.OrderItemResult
+	for _, event := range events {
+		for _, attribute := range event.Attributes {
+			if attribute.Key != types.AttributeKeyOrders {
+				continue
+			}
+			var msgResults []types.OrderItemResult
+			if err := json.Unmarshal([]byte(attribute.Value), &msgResults); err != nil {
+				return nil, fmt.Errorf("invalid results of the orders %q in the response: %s", attribute.Value, err)
+			}
+			itemResults = append(itemResults, msgResults...)
+		}
+	}
+	return itemResults, nil
+}
+
+func orderIdListFromEvents(events types.StringEvents) []string {
+	var orderIdList []string
+	for _, event := range events {
+		for _, attribute := range event.Attributes {
+			if attribute.Key == types.AttributeKeyOrderID {
+				orderIdList = append(orderIdList, attribute.Value)
+			}
+		}
+	}
+	return orderIdList
+}
@@ -0,0 +1,79 @@
+package client
+
+import (
+	"testing"
+
+	"github.com/okex/okchain-go-sdk/client/fakenode"
+	"github.com/okex/okchain-go-sdk/types"
+	"github.com/okex/okchain-go-sdk/types/msg"
+	"github.com/okex/okchain-go-sdk/utils"
+)
+
+func TestGetOrderResultsFromResponse(t *testing.T) {
+	node := fakenode.New()
+	defer node.Close()
+	fromInfo, _, err := utils.CreateAccountWithMnemo(mnemonic, name, passWd)
+	if err != nil {
+		t.Fatal(err)
+	}
+	coins, _ := utils.ParseCoins("10okt")
+	node.AddAccount(fromInfo.GetAddress(), coins)
+	node.AddProduct(types.TokenPair{BaseAssetSymbol: "xxb", QuoteAssetSymbol: "okt", MaxPriceDigit: 4, MaxQuantityDigit: 4, MinQuantity: types.NewDecWithPrec(1, 4)})
+	cli := NewClient(node.Addr())
+
+	// the rejected order in the middle doesn't shift the ids of the others
+	items := []msg.OrderItem{
+		msg.NewOrderItem("xxb_okt", "BUY", "1", "1"),
+		msg.NewOrderItem("xxb_okt", "BUY", "1", "100"),
+		msg.NewOrderItem("xxb_okt", "BUY", "1", "2"),
+	}
+	res, err := cli.NewOrders(fromInfo, items, passWd, "", 0, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	results := GetOrderResultsFromResponse(&res, items)
+	orderIdList := GetOrderIdListFromResponse(&res)
+	if len(results) != 3 || len(orderIdList) != 2 {
+		t.Fatalf("unexpected results %+v of the orders %v", results, orderIdList)
+	}
+	for i, orderID := range []string{orderIdList[0], "", orderIdList[1]} {
+		if result := results[i]; result.Index != i || result.OrderID != orderID {
+			t.Fatalf("unexpected result %d: %+v", i, result)
+		}
+	}
+	if results[0].Status != OrderAccepted || results[2].Status != OrderAccepted || results[0].Message != "" {
+		t.Fatalf("unexpected results of the accepted orders: %+v", results)
+	}
+	if result := results[1]; result.Status != OrderRejected || result.Code != uint32(types.CodeInsufficientCoins) || result.Message == "" {
+		t.Fatalf("unexpected result of the rejected order: %+v", result)
+	}
+	if orderID := GetOrderIdFromResponse(&res); orderID != orderIdList[0] {
+		t.Fatalf("unexpected order id: %s", orderID)
+	}
+
+	// the orders of a failed tx are rejected with its error
+	items = items[1:2]
+	res, err = cli.NewOrders(fromInfo, items, passWd, "", 0, 1)
+	if !types.IsErrInsufficientFunds(err) {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	for i, result := range GetOrderResultsFromResponse(&res, items) {
+		if result.Index != i || result.Status != OrderRejected || result.OrderID != "" || result.Code != res.Code || result.Message == "" {
+			t.Fatalf("unexpected result %d of the failed tx: %+v", i, result)
+		}
+	}
+	if orderID := GetOrderIdFromResponse(&res); orderID != "" {
+		t.Fatalf("unexpected order id of the failed tx: %s", orderID)
+	}
+
+	// the results of the orders of a tx which isn't committed are unknown
+	items = []msg.OrderItem{msg.NewOrderItem("xxb_okt", "BUY", "1", "1")}
+	res, release, err := cli.newOrders(fromInfo, items, passWd, "", 0, 2, BroadcastSync)
+	if err != nil {
+		t.Fatal(err)
+	}
+	release()
+	if result := GetOrderResultsFromResponse(&res, items)[0]; result.Status != OrderUnknown || result.OrderID != "" || result.Message == "" {
+		t.Fatalf("unexpected result of the uncommitted tx: %+v", result)
+	}
+}
@@ -0,0 +1,52 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/okex/okchain-go-sdk/client"
+	"github.com/okex/okchain-go-sdk/crypto/keys"
+)
+
+func configCommand() *command {
+	return &command{
+		name:    "config",
+		args:    "[<key> [<value>]]",
+		short:   "Show the config, or get or set a key of it: node, output, broadcast_mode or keyring_backend",
+		maxArgs: 2,
+		run: func(e *env, args []string) error {
+			if len(args) == 0 {
+				return printJSON(e.stdout, e.cfg)
+			}
+
+			var field *string
+			switch args[0] {
+			case "node":
+				field = &e.cfg.Node
+			case "output":
+				field = &e.cfg.Output
+			case "broadcast_mode":
+				field = &e.cfg.BroadcastMode
+			case "keyring_backend":
+				field = &e.cfg.KeyringBackend
+			default:
+				return fmt.Errorf("unknown config key %q", args[0])
+			}
+			if len(args) == 1 {
+				_, err := fmt.Fprintln(e.stdout, *field)
+				return err
+			}
+
+			value := args[1]
+			switch {
+			case args[0] == "output" && value != outputJSON && value != outputTable:
+				return fmt.Errorf("unsupported output format %q, it's json or table", value)
+			case args[0] == "broadcast_mode" && value != client.BroadcastBlock && value != client.BroadcastSync && value != client.BroadcastAsync:
+				return fmt.Errorf("unsupported broadcast mode %q, it's block, sync or async", value)
+			case args[0] == "keyring_backend" && value != string(keys.BackendLevelDB) && value != string(keys.BackendFile):
+				return fmt.Errorf("unsupported keyring backend %q, it's leveldb or file", value)
+			}
+			*field = value
+			return e.saveConfig()
+		},
+	}
+}
@@ -0,0 +1,180 @@
+package main
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/okex/okchain-go-sdk/client"
+	"github.com/okex/okchain-go-sdk/crypto/keys"
+	"github.com/okex/okchain-go-sdk/utils"
+)
+
+const (
+	defaultNode   = "localhost:26657"
+	outputJSON    = "json"
+	outputTable   = "table"
+	configFile    = "config.json"
+	defaultHome   = ".okgo"
+	passwordInput = "Password: "
+)
+
+// config is the content of the config file. The empty fields fall back to the defaults.
+type config struct {
+	Node          string `json:"node,omitempty"`
+	Output        string `json:"output,omitempty"`
+	BroadcastMode string `json:"broadcast_mode,omitempty"`
+	// KeyringBackend is leveldb, the layout of okchaincli, or file
+	KeyringBackend string `json:"keyring_backend,omitempty"`
+}
+
+// env holds the global options and the resources shared by the commands
+type env struct {
+	stdin  *bufio.Reader
+	stdout io.Writer
+	stderr io.Writer
+
+	// global flags
+	home       string
+	configPath string
+	node       string
+	output     string
+
+	cfg config
+	kb  keys.Keybase
+}
+
+// registerGlobalFlags registers the global flags, keeping the values parsed by a previous flag set
+func (e *env) registerGlobalFlags(fs *flag.FlagSet) {
+	fs.StringVar(&e.home, "home", e.home, "directory of the keybase and the config file (default $HOME/"+defaultHome+")")
+	fs.StringVar(&e.configPath, "config", e.configPath, "path of the config file (default <home>/"+configFile+")")
+	fs.StringVar(&e.node, "node", e.node, "rpc address of the node (default "+defaultNode+")")
+	fs.StringVar(&e.output, "output", e.output, "output format: json or table (default json)")
+}
+
+// loadConfig reads the config file and fills the global options not given by the flags
+func (e *env) loadConfig() error {
+	if e.home == "" {
+		userHome, err := os.UserHomeDir()
+		if err != nil {
+			return fmt.Errorf("failed to find the home directory, --home is required: %s", err)
+		}
+		e.home = filepath.Join(userHome, defaultHome)
+	}
+	if e.configPath == "" {
+		e.configPath = filepath.Join(e.home, configFile)
+	}
+
+	bz, err := ioutil.ReadFile(e.configPath)
+	switch {
+	case os.IsNotExist(err):
+	case err != nil:
+		return err
+	default:
+		if err := json.Unmarshal(bz, &e.cfg); err != nil {
+			return fmt.Errorf("invalid config file %s: %s", e.configPath, err)
+		}
+	}
+
+	if e.node == "" {
+		e.node = e.cfg.Node
+	}
+	if e.node == "" {
+		e.node = defaultNode
+	}
+	if e.output == "" {
+		e.output = e.cfg.Output
+	}
+	if e.output == "" {
+		e.output = outputJSON
+	}
+	if e.output != outputJSON && e.output != outputTable {
+		return fmt.Errorf("unsupported output format %q, it's json or table", e.output)
+	}
+	return nil
+}
+
+func (e *env) saveConfig() error {
+	bz, err := json.MarshalIndent(e.cfg, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(e.configPath), 0700); err != nil {
+		return err
+	}
+	return ioutil.WriteFile(e.configPath, append(bz, '\n'), 0600)
+}
+
+func (e *env) client() client.OKChainClient {
+	return client.NewClient(e.node)
+}
+
+// keybase opens the keybase of the configured backend under the home directory. It's also set as
+// utils.Kb, which signs the txs of the sdk.
+func (e *env) keybase() (keys.Keybase, error) {
+	if e.kb != nil {
+		return e.kb, nil
+	}
+	kb, err := openKeybase(e.cfg.KeyringBackend, e.home)
+	if err != nil {
+		return nil, err
+	}
+	e.kb = kb
+	utils.Kb = kb
+	return kb, nil
+}
+
+// openKeybase opens the keybase of the backend under the home directory, the backend is leveldb
+// if it's empty
+func openKeybase(backend, home string) (keys.Keybase, error) {
+	if backend == "" {
+		backend = string(keys.BackendLevelDB)
+	}
+	if backend == string(keys.BackendMemory) {
+		return nil, errors.New("the memory keybase backend would lose the keys, it's leveldb or file")
+	}
+	kb, err := keys.NewKeybase(keys.Backend(backend), home)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open the %s keybase in %s: %s", backend, home, err)
+	}
+	return kb, nil
+}
+
+func (e *env) close() {
+	if e.kb != nil {
+		e.kb.CloseDB()
+		e.kb = nil
+	}
+}
+
+// password returns the password given by the flag or reads it from the stdin
+func (e *env) password(flagValue string) (string, error) {
+	if flagValue != "" {
+		return flagValue, nil
+	}
+	fmt.Fprint(e.stderr, passwordInput)
+	line, err := e.stdin.ReadString('\n')
+	if err != nil && (err != io.EOF || line == "") {
+		return "", fmt.Errorf("failed to read the password: %s", err)
+	}
+	password := strings.TrimRight(line, "\r\n")
+	if password == "" {
+		return "", errors.New("empty password")
+	}
+	return password, nil
+}
+
+// readInput reads the file at the path, or the stdin if the path is "-"
+func (e *env) readInput(path string) ([]byte, error) {
+	if path == "-" {
+		return ioutil.ReadAll(e.stdin)
+	}
+	return ioutil.ReadFile(path)
+}
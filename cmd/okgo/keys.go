@@ -0,0 +1,312 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"strings"
+
+	"github.com/okex/okchain-go-sdk/crypto/go-bip39"
+	"github.com/okex/okchain-go-sdk/crypto/keys"
+	"github.com/okex/okchain-go-sdk/crypto/keys/hd"
+	"github.com/okex/okchain-go-sdk/crypto/keys/keystore"
+	"github.com/okex/okchain-go-sdk/types"
+	"github.com/okex/okchain-go-sdk/utils"
+)
+
+// keyOutput is the printed form of a key
+type keyOutput struct {
+	Name     string `json:"name"`
+	Type     string `json:"type"`
+	Address  string `json:"address"`
+	PubKey   string `json:"pubkey"`
+	Mnemonic string `json:"mnemonic,omitempty"`
+}
+
+func newKeyOutput(name string, info keys.Info) (keyOutput, error) {
+	pubKey, err := types.Bech32ifyAccPub(info.GetPubKey())
+	if err != nil {
+		return keyOutput{}, err
+	}
+	return keyOutput{
+		Name:    name,
+		Type:    info.GetType().String(),
+		Address: info.GetAddress().String(),
+		PubKey:  pubKey,
+	}, nil
+}
+
+func keysCommands() []*command {
+	return []*command{
+		keysCreateCmd(),
+		keysImportCmd(),
+		keysExportCmd(),
+		keysListCmd(),
+		keysMigrateCmd(),
+		keysWatchCmd(),
+	}
+}
+
+func keysCreateCmd() *command {
+	var mnemonic, password, hdPath string
+	var params utils.AccountParams
+	var account, index uint
+	return &command{
+		name:    "create",
+		args:    "<name>",
+		short:   "Create a key from a new mnemonic, or recover it from --mnemonic",
+		minArgs: 1,
+		maxArgs: 1,
+		flags: func(fs *flag.FlagSet) {
+			fs.StringVar(&mnemonic, "mnemonic", "", "mnemonic to recover the key from")
+			fs.StringVar(&password, "password", "", "password to encrypt the key with, read from the stdin if it's not given")
+			fs.IntVar(&params.MnemonicWords, "words", 12, "number of words of a new mnemonic: 12, 15, 18, 21 or 24")
+			fs.StringVar(&params.BIP39Passphrase, "bip39-passphrase", "", "optional BIP39 passphrase of the mnemonic")
+			fs.StringVar(&params.Language, "language", bip39.English, "language of the BIP39 wordlist of a new mnemonic, like japanese or chinese_simplified")
+			fs.UintVar(&account, "account", 0, "account of the BIP44 path 44'/996'/<account>'/0/<index>")
+			fs.UintVar(&index, "index", 0, "address index of the BIP44 path 44'/996'/<account>'/0/<index>")
+			fs.StringVar(&hdPath, "hd-path", "", "full BIP44 path of the key, overriding --account and --index")
+		},
+		run: func(e *env, args []string) error {
+			name := args[0]
+			if hdPath != "" {
+				var err error
+				if params.HDPath, err = hd.NewParamsFromPath(hdPath); err != nil {
+					return fmt.Errorf("invalid hd path %q: %s", hdPath, err)
+				}
+			} else {
+				params.HDPath = hd.NewFundraiserParams(uint32(account), uint32(index))
+			}
+			kb, err := e.keybase()
+			if err != nil {
+				return err
+			}
+			if _, err := kb.Get(name); err == nil {
+				return fmt.Errorf("key %s already exists", name)
+			}
+			password, err := e.password(password)
+			if err != nil {
+				return err
+			}
+
+			var info keys.Info
+			if mnemonic == "" {
+				info, mnemonic, err = utils.CreateAccountWithParams(name, password, params)
+			} else {
+				info, mnemonic, err = utils.CreateAccountWithMnemoAndParams(strings.TrimSpace(mnemonic), name, password, params)
+			}
+			if err != nil {
+				return err
+			}
+
+			out, err := newKeyOutput(name, info)
+			if err != nil {
+				return err
+			}
+			out.Mnemonic = mnemonic
+			return e.print(out)
+		},
+	}
+}
+
+func keysImportCmd() *command {
+	var keyStore bool
+	var password, keyStorePassword string
+	return &command{
+		name:    "import",
+		args:    "<name> <file>",
+		short:   "Import a key exported in the ASCII armor format or with --keystore, the file is read from the stdin if it's -",
+		minArgs: 2,
+		maxArgs: 2,
+		flags: func(fs *flag.FlagSet) {
+			fs.BoolVar(&keyStore, "keystore", false, "import a key of the JSON keystore format")
+			fs.StringVar(&password, "password", "", "password to encrypt the key of a keystore with, read from the stdin if it's not given")
+			fs.StringVar(&keyStorePassword, "keystore-password", "", "password of the keystore, the same as --password if it's not given")
+		},
+		run: func(e *env, args []string) error {
+			name := args[0]
+			bz, err := e.readInput(args[1])
+			if err != nil {
+				return err
+			}
+			kb, err := e.keybase()
+			if err != nil {
+				return err
+			}
+
+			var info keys.Info
+			if keyStore {
+				if password, err = e.password(password); err != nil {
+					return err
+				}
+				if keyStorePassword == "" {
+					keyStorePassword = password
+				}
+				if info, err = kb.ImportKeyStore(name, bz, keyStorePassword, password); err != nil {
+					return err
+				}
+			} else {
+				if err := kb.Import(name, string(bz)); err != nil {
+					return err
+				}
+				if info, err = kb.Get(name); err != nil {
+					return err
+				}
+			}
+
+			out, err := newKeyOutput(name, info)
+			if err != nil {
+				return err
+			}
+			return e.print(out)
+		},
+	}
+}
+
+func keysExportCmd() *command {
+	var keyStore, lightKDF bool
+	var password, keyStorePassword, hdPath string
+	return &command{
+		name:    "export",
+		args:    "<name>",
+		short:   "Export a key in the ASCII armor format, whose private key stays encrypted by its password, or with --keystore",
+		minArgs: 1,
+		maxArgs: 1,
+		flags: func(fs *flag.FlagSet) {
+			fs.BoolVar(&keyStore, "keystore", false, "export the key in the JSON keystore format, encrypted by scrypt and AES-GCM")
+			fs.StringVar(&password, "password", "", "password of the key, read from the stdin if it's not given")
+			fs.StringVar(&keyStorePassword, "keystore-password", "", "password to encrypt the keystore with, the same as --password if it's not given")
+			fs.StringVar(&hdPath, "hd-path", "", "BIP44 path of the key recorded in the keystore")
+			fs.BoolVar(&lightKDF, "light-kdf", false, "use the light scrypt params, which is faster but weaker")
+		},
+		run: func(e *env, args []string) error {
+			kb, err := e.keybase()
+			if err != nil {
+				return err
+			}
+			if !keyStore {
+				armor, err := kb.Export(args[0])
+				if err != nil {
+					return err
+				}
+				_, err = fmt.Fprintln(e.stdout, armor)
+				return err
+			}
+
+			if password, err = e.password(password); err != nil {
+				return err
+			}
+			if keyStorePassword == "" {
+				keyStorePassword = password
+			}
+			params := keystore.DefaultScryptParams
+			if lightKDF {
+				params = keystore.LightScryptParams
+			}
+			keyJSON, err := kb.ExportKeyStore(args[0], password, keyStorePassword, hdPath, params)
+			if err != nil {
+				return err
+			}
+			_, err = fmt.Fprintln(e.stdout, string(keyJSON))
+			return err
+		},
+	}
+}
+
+func keysListCmd() *command {
+	return &command{
+		name:  "list",
+		short: "List the keys of the keybase",
+		run: func(e *env, args []string) error {
+			kb, err := e.keybase()
+			if err != nil {
+				return err
+			}
+			infos, err := kb.List()
+			if err != nil {
+				return err
+			}
+			outs := make([]keyOutput, len(infos))
+			for i, info := range infos {
+				if outs[i], err = newKeyOutput(info.GetName(), info); err != nil {
+					return err
+				}
+			}
+			return e.print(outs)
+		},
+	}
+}
+
+func keysMigrateCmd() *command {
+	var backend string
+	return &command{
+		name:    "migrate",
+		args:    "<from-home>",
+		short:   "Copy the keys of another home, like ~/.okchaincli, into the keybase, the private keys stay encrypted by their passwords",
+		minArgs: 1,
+		maxArgs: 1,
+		flags: func(fs *flag.FlagSet) {
+			fs.StringVar(&backend, "from-backend", string(keys.BackendLevelDB), "keybase backend of the other home: leveldb or file")
+		},
+		run: func(e *env, args []string) error {
+			src, err := openKeybase(backend, args[0])
+			if err != nil {
+				return err
+			}
+			defer src.CloseDB()
+			kb, err := e.keybase()
+			if err != nil {
+				return err
+			}
+
+			migrated, err := keys.Migrate(src, kb)
+			if err != nil {
+				return err
+			}
+			outputs := make([]keyOutput, len(migrated))
+			for i, name := range migrated {
+				info, err := kb.Get(name)
+				if err != nil {
+					return err
+				}
+				if outputs[i], err = newKeyOutput(name, info); err != nil {
+					return err
+				}
+			}
+			return e.print(outputs)
+		},
+	}
+}
+
+func keysWatchCmd() *command {
+	var hdPath string
+	return &command{
+		name:    "watch",
+		args:    "<name> <xpub>",
+		short:   "Store the public key of --hd-path under the extended public key as an offline key, which can't sign",
+		minArgs: 2,
+		maxArgs: 2,
+		flags: func(fs *flag.FlagSet) {
+			fs.StringVar(&hdPath, "hd-path", "0/0", "non-hardened path of the key under the xpub")
+		},
+		run: func(e *env, args []string) error {
+			name := args[0]
+			kb, err := e.keybase()
+			if err != nil {
+				return err
+			}
+			if _, err := kb.Get(name); err == nil {
+				return fmt.Errorf("key %s already exists", name)
+			}
+
+			info, err := utils.CreateWatchOnlyAccount(args[1], hdPath, name)
+			if err != nil {
+				return err
+			}
+			out, err := newKeyOutput(name, info)
+			if err != nil {
+				return err
+			}
+			return e.print(out)
+		},
+	}
+}
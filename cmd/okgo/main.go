@@ -0,0 +1,158 @@
+// Command okgo is a command line client of OKChain built on the okchain-go-sdk. It manages the keys
+// in a local keybase, queries the chain and signs and broadcasts txs:
+//
+//	okgo [global flags] keys create|import|export|list ...
+//	okgo [global flags] query account|tokens|products|depthbook|tickers|candles|orders|deals|proposals|validators|block|tx ...
+//	okgo [global flags] tx send|multisend|order|cancel|sign|broadcast ...
+//	okgo [global flags] config [<key> [<value>]]
+//
+// The global flags can be given before or after the subcommand. Their defaults are read from the
+// config file, which is $HOME/.okgo/config.json unless --home or --config is given.
+package main
+
+import (
+	"bufio"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+)
+
+func main() {
+	if err := run(os.Args[1:], os.Stdin, os.Stdout, os.Stderr); err != nil {
+		fmt.Fprintln(os.Stderr, "Error:", err)
+		os.Exit(1)
+	}
+}
+
+// command is a leaf command of okgo
+type command struct {
+	name    string
+	args    string
+	short   string
+	minArgs int
+	// maxArgs is the max number of positional arguments, -1 if it's unlimited
+	maxArgs int
+	flags   func(fs *flag.FlagSet)
+	run     func(e *env, args []string) error
+}
+
+func commandGroups() map[string][]*command {
+	return map[string][]*command{
+		"keys":  keysCommands(),
+		"query": queryCommands(),
+		"tx":    txCommands(),
+	}
+}
+
+// run executes okgo with the given arguments, without the program name
+func run(args []string, stdin io.Reader, stdout, stderr io.Writer) error {
+	e := &env{
+		stdin:  bufio.NewReader(stdin),
+		stdout: stdout,
+		stderr: stderr,
+	}
+	defer e.close()
+
+	root := flag.NewFlagSet("okgo", flag.ContinueOnError)
+	root.SetOutput(stderr)
+	e.registerGlobalFlags(root)
+	root.Usage = func() { printUsage(stderr, root) }
+	if err := root.Parse(args); err != nil {
+		if err == flag.ErrHelp {
+			return nil
+		}
+		return err
+	}
+
+	args = root.Args()
+	if len(args) == 0 {
+		root.Usage()
+		return errors.New("no command given")
+	}
+	if args[0] == "config" {
+		return runCommand(e, "okgo", configCommand(), args[1:])
+	}
+
+	groups := commandGroups()
+	group, ok := groups[args[0]]
+	if !ok {
+		root.Usage()
+		return fmt.Errorf("unknown command %q", args[0])
+	}
+	prefix := "okgo " + args[0]
+	if len(args) == 1 || args[1] == "-h" || args[1] == "--help" || args[1] == "help" {
+		printGroupUsage(stderr, prefix, group)
+		return nil
+	}
+	for _, cmd := range group {
+		if cmd.name == args[1] {
+			return runCommand(e, prefix, cmd, args[2:])
+		}
+	}
+	printGroupUsage(stderr, prefix, group)
+	return fmt.Errorf("unknown command %q", prefix+" "+args[1])
+}
+
+func runCommand(e *env, prefix string, cmd *command, args []string) error {
+	fs := flag.NewFlagSet(prefix+" "+cmd.name, flag.ContinueOnError)
+	fs.SetOutput(e.stderr)
+	if cmd.flags != nil {
+		cmd.flags(fs)
+	}
+	e.registerGlobalFlags(fs)
+	fs.Usage = func() {
+		fmt.Fprintf(e.stderr, "Usage: %s [flags] %s\n\n%s\n\nFlags:\n", fs.Name(), cmd.args, cmd.short)
+		fs.PrintDefaults()
+	}
+	if err := fs.Parse(args); err != nil {
+		if err == flag.ErrHelp {
+			return nil
+		}
+		return err
+	}
+
+	args = fs.Args()
+	if len(args) < cmd.minArgs || (cmd.maxArgs >= 0 && len(args) > cmd.maxArgs) {
+		fs.Usage()
+		return fmt.Errorf("wrong number of arguments for %q", fs.Name())
+	}
+
+	if err := e.loadConfig(); err != nil {
+		return err
+	}
+	return cmd.run(e, args)
+}
+
+func printUsage(w io.Writer, root *flag.FlagSet) {
+	fmt.Fprintln(w, "Usage: okgo [flags] <command> [<subcommand>] [flags] [args]")
+	fmt.Fprintln(w)
+	fmt.Fprintln(w, "Commands:")
+	groups := commandGroups()
+	names := make([]string, 0, len(groups))
+	for name := range groups {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		var subs []string
+		for _, cmd := range groups[name] {
+			subs = append(subs, cmd.name)
+		}
+		fmt.Fprintf(w, "  %-8s %s\n", name, strings.Join(subs, ", "))
+	}
+	fmt.Fprintf(w, "  %-8s %s\n", "config", configCommand().short)
+	fmt.Fprintln(w)
+	fmt.Fprintln(w, "Flags:")
+	root.PrintDefaults()
+}
+
+func printGroupUsage(w io.Writer, prefix string, group []*command) {
+	fmt.Fprintf(w, "Usage: %s <subcommand> [flags] [args]\n\nSubcommands:\n", prefix)
+	for _, cmd := range group {
+		fmt.Fprintf(w, "  %-11s %s\n", cmd.name, cmd.short)
+	}
+}
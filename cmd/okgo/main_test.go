@@ -0,0 +1,187 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/okex/okchain-go-sdk/client/fakenode"
+	"github.com/okex/okchain-go-sdk/crypto/go-bip39"
+	"github.com/okex/okchain-go-sdk/types"
+	"github.com/okex/okchain-go-sdk/utils"
+	"golang.org/x/text/unicode/norm"
+)
+
+const (
+	passWd   = "12345678"
+	mnemonic = "total lottery arena when pudding best candy until army spoil drill pool"
+	addr     = "okchain1g7c3nvac7mjgn2m9mqllgat8wwd3aptdqket5k"
+)
+
+func okgo(t *testing.T, args ...string) string {
+	t.Helper()
+	var stdout, stderr bytes.Buffer
+	if err := run(args, strings.NewReader(passWd+"\n"), &stdout, &stderr); err != nil {
+		t.Fatalf("okgo %s: %s\n%s", strings.Join(args, " "), err, stderr.String())
+	}
+	return stdout.String()
+}
+
+func createKey(t *testing.T, home string, args ...string) keyOutput {
+	var key keyOutput
+	out := okgo(t, append([]string{"--home", home, "keys", "create"}, args...)...)
+	if err := json.Unmarshal([]byte(out), &key); err != nil {
+		t.Fatalf("invalid key output %s: %s", out, err)
+	}
+	return key
+}
+
+func TestOkgo(t *testing.T) {
+	home, err := ioutil.TempDir("", "okgo")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(home)
+
+	node := fakenode.New()
+	defer node.Close()
+	okgo(t, "--home", home, "config", "node", node.Addr())
+
+	// keys
+	alice := createKey(t, home, "--mnemonic", mnemonic, "--password", passWd, "alice")
+	if alice.Address != addr || alice.Mnemonic != mnemonic {
+		t.Fatalf("unexpected key recovered from the mnemonic: %+v", alice)
+	}
+	// the password is read from the stdin
+	bob := createKey(t, home, "bob")
+	if len(strings.Fields(bob.Mnemonic)) != 12 {
+		t.Fatalf("unexpected mnemonic of a new key: %s", bob.Mnemonic)
+	}
+	carol := createKey(t, home, "--words", "24", "--index", "1", "--password", passWd, "carol")
+	if len(strings.Fields(carol.Mnemonic)) != 24 {
+		t.Fatalf("unexpected mnemonic of 24 words: %s", carol.Mnemonic)
+	}
+	if index1 := createKey(t, filepath.Join(home, "index"), "--mnemonic", mnemonic, "--hd-path", "44'/996'/0'/0/1", "--password", passWd, "alice"); index1.Address == addr {
+		t.Fatal("the hd path doesn't change the key")
+	}
+	// a mnemonic in japanese is separated by ideographic spaces, and the official vector typed in
+	// composed characters with its passphrase recovers the key of its NFKD form
+	langHome := filepath.Join(home, "lang")
+	if japanese := createKey(t, langHome, "--language", "japanese", "--password", passWd, "alice"); len(strings.Split(japanese.Mnemonic, "\u3000")) != 12 {
+		t.Fatalf("unexpected mnemonic in japanese: %s", japanese.Mnemonic)
+	}
+	vectorMnemonic := "そつう　れきだい　ほんやく　わかす　りくつ　ばいか　ろせん　やちん　そつう　れきだい　ほんやく　わかめ"
+	vectorPassphrase := "㍍ガバヴァぱばぐゞちぢ十人十色"
+	composed := createKey(t, langHome, "--mnemonic", vectorMnemonic, "--bip39-passphrase", vectorPassphrase, "--password", passWd, "composed")
+	decomposed := createKey(t, langHome, "--mnemonic", bip39.NormalizeMnemonic(vectorMnemonic), "--bip39-passphrase", norm.NFKD.String(vectorPassphrase), "--password", passWd, "decomposed")
+	if composed.Address != decomposed.Address {
+		t.Fatalf("the japanese vector isn't normalized: %s %s", composed.Address, decomposed.Address)
+	}
+	_, xpub, err := utils.GenerateExtendedKeysFromMnemo(mnemonic, "", "44'/996'/0'")
+	if err != nil {
+		t.Fatal(err)
+	}
+	var watched keyOutput
+	if err := json.Unmarshal([]byte(okgo(t, "--home", filepath.Join(home, "watch"), "keys", "watch", "watched", xpub)), &watched); err != nil {
+		t.Fatal(err)
+	}
+	if watched.Address != addr || watched.Type != "offline" {
+		t.Fatalf("unexpected watch-only key: %+v", watched)
+	}
+	var listed []keyOutput
+	if err := json.Unmarshal([]byte(okgo(t, "keys", "list", "--home", home)), &listed); err != nil {
+		t.Fatal(err)
+	}
+	if len(listed) != 3 || listed[0].Name != "alice" || listed[1].Name != "bob" || listed[2].Name != "carol" {
+		t.Fatalf("unexpected keys listed: %+v", listed)
+	}
+
+	otherHome := filepath.Join(home, "other")
+	armorPath := filepath.Join(home, "alice.armor")
+	if err := ioutil.WriteFile(armorPath, []byte(okgo(t, "keys", "export", "--home", home, "alice")), 0600); err != nil {
+		t.Fatal(err)
+	}
+	if out := okgo(t, "keys", "import", "--home", otherHome, "carol", armorPath); !strings.Contains(out, addr) {
+		t.Fatalf("unexpected key imported: %s", out)
+	}
+	keyStorePath := filepath.Join(home, "alice.json")
+	keyJSON := okgo(t, "keys", "export", "--home", home, "--keystore", "--light-kdf", "--keystore-password", "exported", "alice")
+	if err := ioutil.WriteFile(keyStorePath, []byte(keyJSON), 0600); err != nil {
+		t.Fatal(err)
+	}
+	if out := okgo(t, "keys", "import", "--home", otherHome, "--keystore", "--keystore-password", "exported", "dave", keyStorePath); !strings.Contains(out, addr) {
+		t.Fatalf("unexpected key imported from the keystore: %s", out)
+	}
+	// copy the keys of the leveldb home into a file keybase
+	fileHome := filepath.Join(home, "file")
+	okgo(t, "--home", fileHome, "config", "keyring_backend", "file")
+	var migrated []keyOutput
+	if err := json.Unmarshal([]byte(okgo(t, "--home", fileHome, "keys", "migrate", home)), &migrated); err != nil {
+		t.Fatal(err)
+	}
+	if len(migrated) != 3 || migrated[0].Address != addr || migrated[1].Address != bob.Address {
+		t.Fatalf("unexpected keys migrated: %+v", migrated)
+	}
+
+	// queries
+	aliceAddr, _ := types.AccAddressFromBech32(alice.Address)
+	bobAddr, _ := types.AccAddressFromBech32(bob.Address)
+	coins, _ := utils.ParseCoins("1000okt")
+	node.AddAccount(aliceAddr, coins)
+	node.AddToken(types.Token{Symbol: "okt", OriginalSymbol: "okt", TotalSupply: types.NewDec(1000000), Owner: aliceAddr})
+
+	if out := okgo(t, "--home", home, "query", "account", addr); !strings.Contains(out, addr) {
+		t.Fatalf("unexpected account: %s", out)
+	}
+	out := okgo(t, "--home", home, "--output", "table", "query", "tokens")
+	if lines := strings.Split(strings.TrimSpace(out), "\n"); len(lines) != 2 || !strings.HasPrefix(lines[0], "DESCRIPTION") || !strings.Contains(lines[1], "okt") {
+		t.Fatalf("unexpected table of the tokens:\n%s", out)
+	}
+	if out := okgo(t, "--home", home, "query", "block", "--output", "table", "1"); strings.Join(strings.Fields(strings.Split(out, "\n")[0]), " ") != "height 1" {
+		t.Fatalf("unexpected table of the block:\n%s", out)
+	}
+
+	// txs
+	okgo(t, "--home", home, "tx", "send", "--from", "alice", "--password", passWd, bob.Address, "100okt")
+	if got := node.Coins(bobAddr).String(); got != "100.00000000okt" {
+		t.Fatalf("unexpected coins of the receiver: %s", got)
+	}
+
+	unsignedPath := filepath.Join(home, "unsigned.json")
+	signedPath := filepath.Join(home, "signed.json")
+	unsigned := okgo(t, "--home", home, "tx", "send", "--generate-only", "--from", addr, "--memo", "offline", bob.Address, "50okt")
+	if err := ioutil.WriteFile(unsignedPath, []byte(unsigned), 0600); err != nil {
+		t.Fatal(err)
+	}
+	signed := okgo(t, "--home", home, "tx", "sign", "--from", "alice", "--password", passWd, unsignedPath)
+	if err := ioutil.WriteFile(signedPath, []byte(signed), 0600); err != nil {
+		t.Fatal(err)
+	}
+	okgo(t, "--home", home, "tx", "broadcast", signedPath)
+	if got := node.Coins(bobAddr).String(); got != "150.00000000okt" {
+		t.Fatalf("unexpected coins of the receiver after the offline signed tx: %s", got)
+	}
+
+	// the txs are broadcast in the mode of the config
+	okgo(t, "--home", home, "config", "broadcast_mode", "sync")
+	out = okgo(t, "--home", home, "tx", "send", "--from", "alice", "--password", passWd, bob.Address, "10okt")
+	if strings.Contains(out, `"height"`) {
+		t.Fatalf("unexpected response of a sync broadcast: %s", out)
+	}
+	if got := node.Coins(bobAddr).String(); got != "160.00000000okt" {
+		t.Fatalf("unexpected coins of the receiver after the sync tx: %s", got)
+	}
+
+	// errors
+	var stdout, stderr bytes.Buffer
+	if err := run([]string{"--home", home, "tx", "broadcast", unsignedPath}, strings.NewReader(""), &stdout, &stderr); err == nil {
+		t.Fatal("an unsigned tx is broadcast")
+	}
+	if err := run([]string{"--home", home, "query", "unknown"}, strings.NewReader(""), &stdout, &stderr); err == nil {
+		t.Fatal("an unknown command runs")
+	}
+}
@@ -0,0 +1,125 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"reflect"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/okex/okchain-go-sdk/crypto/encoding/codec"
+)
+
+// print writes the result in the output format. The JSON is encoded by amino like the one of
+// okchaincli, unless the result can't be encoded by amino, e.g. because it has float fields.
+func (e *env) print(v interface{}) error {
+	return e.printWithTable(v, v)
+}
+
+// printWithTable writes the result in the output format, using the given summary for the table
+func (e *env) printWithTable(v, table interface{}) error {
+	if e.output == outputTable {
+		return printTable(e.stdout, table)
+	}
+	return printJSON(e.stdout, v)
+}
+
+func printJSON(w io.Writer, v interface{}) error {
+	bz, err := codec.Cdc.MarshalJSONIndent(v, "", "  ")
+	if err != nil {
+		if bz, err = json.MarshalIndent(v, "", "  "); err != nil {
+			return err
+		}
+	}
+	_, err = fmt.Fprintln(w, string(bz))
+	return err
+}
+
+// printTable writes a slice as a table with a row per element and a struct as a table with a row
+// per field
+func printTable(w io.Writer, v interface{}) error {
+	tw := tabwriter.NewWriter(w, 0, 4, 2, ' ', 0)
+	rv := indirect(reflect.ValueOf(v))
+	switch {
+	case !rv.IsValid():
+	case rv.Kind() == reflect.Slice && rv.Type().Elem().Kind() == reflect.Slice:
+		for i := 0; i < rv.Len(); i++ {
+			var cells []string
+			row := rv.Index(i)
+			for j := 0; j < row.Len(); j++ {
+				cells = append(cells, cell(row.Index(j)))
+			}
+			fmt.Fprintln(tw, strings.Join(cells, "\t"))
+		}
+	case rv.Kind() == reflect.Slice:
+		var header []string
+		for i := 0; i < rv.Len(); i++ {
+			names, values := fields(indirect(rv.Index(i)))
+			if header == nil {
+				header = names
+				fmt.Fprintln(tw, strings.ToUpper(strings.Join(header, "\t")))
+			}
+			fmt.Fprintln(tw, strings.Join(values, "\t"))
+		}
+	case rv.Kind() == reflect.Struct:
+		names, values := fields(rv)
+		for i := range names {
+			fmt.Fprintf(tw, "%s\t%s\n", names[i], values[i])
+		}
+	default:
+		fmt.Fprintln(tw, cell(rv))
+	}
+	return tw.Flush()
+}
+
+// fields returns the names and the values of the exported fields of a struct. The fields of the
+// embedded structs are inlined.
+func fields(rv reflect.Value) (names, values []string) {
+	if rv.Kind() != reflect.Struct {
+		return []string{"value"}, []string{cell(rv)}
+	}
+	rt := rv.Type()
+	for i := 0; i < rt.NumField(); i++ {
+		field := rt.Field(i)
+		if field.PkgPath != "" {
+			continue
+		}
+		name := strings.Split(field.Tag.Get("json"), ",")[0]
+		if name == "-" {
+			continue
+		}
+		if field.Anonymous && name == "" {
+			if embedded := indirect(rv.Field(i)); embedded.Kind() == reflect.Struct {
+				embeddedNames, embeddedValues := fields(embedded)
+				names = append(names, embeddedNames...)
+				values = append(values, embeddedValues...)
+				continue
+			}
+		}
+		if name == "" {
+			name = field.Name
+		}
+		names = append(names, name)
+		values = append(values, cell(rv.Field(i)))
+	}
+	return
+}
+
+func cell(rv reflect.Value) string {
+	if !rv.IsValid() || ((rv.Kind() == reflect.Ptr || rv.Kind() == reflect.Interface) && rv.IsNil()) {
+		return ""
+	}
+	s := fmt.Sprint(rv.Interface())
+	return strings.Join(strings.Fields(s), " ")
+}
+
+func indirect(rv reflect.Value) reflect.Value {
+	for rv.IsValid() && (rv.Kind() == reflect.Ptr || rv.Kind() == reflect.Interface) {
+		if rv.IsNil() {
+			return reflect.Value{}
+		}
+		rv = rv.Elem()
+	}
+	return rv
+}
@@ -0,0 +1,400 @@
+package main
+
+import (
+	"encoding/hex"
+	"flag"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/okex/okchain-go-sdk/types"
+)
+
+func queryCommands() []*command {
+	return []*command{
+		queryAccountCmd(),
+		queryTokensCmd(),
+		queryProductsCmd(),
+		queryDepthbookCmd(),
+		queryTickersCmd(),
+		queryCandlesCmd(),
+		queryOrdersCmd(),
+		queryDealsCmd(),
+		queryProposalsCmd(),
+		queryValidatorsCmd(),
+		queryBlockCmd(),
+		queryTxCmd(),
+	}
+}
+
+// pagingFlags are the flags of the backend queries with a time range and pages
+type pagingFlags struct {
+	start, end, page, perPage int
+}
+
+func (p *pagingFlags) register(fs *flag.FlagSet) {
+	fs.IntVar(&p.start, "start", 0, "start of the time range, in unix seconds")
+	fs.IntVar(&p.end, "end", 0, "end of the time range, in unix seconds (default now)")
+	fs.IntVar(&p.page, "page", 1, "page number")
+	fs.IntVar(&p.perPage, "per-page", 50, "number of the entries per page")
+}
+
+func (p *pagingFlags) endOrNow() int {
+	if p.end == 0 {
+		return int(time.Now().Unix())
+	}
+	return p.end
+}
+
+func queryAccountCmd() *command {
+	return &command{
+		name:    "account",
+		args:    "<address>",
+		short:   "Query the account number, the sequence and the coins of an account",
+		minArgs: 1,
+		maxArgs: 1,
+		run: func(e *env, args []string) error {
+			cli := e.client()
+			acc, err := cli.GetAccountInfoByAddr(args[0])
+			if err != nil {
+				return err
+			}
+			return e.print(acc)
+		},
+	}
+}
+
+func queryTokensCmd() *command {
+	var symbol string
+	return &command{
+		name:    "tokens",
+		args:    "[<address>]",
+		short:   "Query the tokens issued on the chain, or the balances of the tokens of an address",
+		maxArgs: 1,
+		flags: func(fs *flag.FlagSet) {
+			fs.StringVar(&symbol, "symbol", "", "query a single token")
+		},
+		run: func(e *env, args []string) error {
+			cli := e.client()
+			switch {
+			case len(args) == 1 && symbol != "":
+				tokens, err := cli.GetTokenInfoByAddr(args[0], symbol)
+				if err != nil {
+					return err
+				}
+				return e.printWithTable(tokens, tokens.Currencies)
+			case len(args) == 1:
+				tokens, err := cli.GetTokensInfoByAddr(args[0])
+				if err != nil {
+					return err
+				}
+				return e.printWithTable(tokens, tokens.Currencies)
+			case symbol != "":
+				token, err := cli.GetTokenInfo(symbol)
+				if err != nil {
+					return err
+				}
+				return e.print(token)
+			default:
+				tokens, err := cli.GetTokensInfo()
+				if err != nil {
+					return err
+				}
+				return e.print(tokens)
+			}
+		},
+	}
+}
+
+func queryProductsCmd() *command {
+	return &command{
+		name:  "products",
+		short: "Query the token pairs listed on the dex",
+		run: func(e *env, args []string) error {
+			cli := e.client()
+			products, err := cli.GetProductsInfo()
+			if err != nil {
+				return err
+			}
+			return e.print(products)
+		},
+	}
+}
+
+// depthbookRow is a row of the depthbook in the table output
+type depthbookRow struct {
+	Side     string `json:"side"`
+	Price    string `json:"price"`
+	Quantity string `json:"quantity"`
+}
+
+func queryDepthbookCmd() *command {
+	return &command{
+		name:    "depthbook",
+		args:    "<product>",
+		short:   "Query the depthbook of a product",
+		minArgs: 1,
+		maxArgs: 1,
+		run: func(e *env, args []string) error {
+			cli := e.client()
+			book, err := cli.GetDepthbookInfo(args[0])
+			if err != nil {
+				return err
+			}
+			var rows []depthbookRow
+			for _, ask := range book.Asks {
+				rows = append(rows, depthbookRow{"ask", ask.Price, ask.Quantity})
+			}
+			for _, bid := range book.Bids {
+				rows = append(rows, depthbookRow{"bid", bid.Price, bid.Quantity})
+			}
+			return e.printWithTable(book, rows)
+		},
+	}
+}
+
+func queryTickersCmd() *command {
+	var count int
+	return &command{
+		name:  "tickers",
+		short: "Query the tickers of the products",
+		flags: func(fs *flag.FlagSet) {
+			fs.IntVar(&count, "count", 100, "max number of the tickers")
+		},
+		run: func(e *env, args []string) error {
+			cli := e.client()
+			tickers, err := cli.GetTickersInfo(count)
+			if err != nil {
+				return err
+			}
+			return e.print(tickers)
+		},
+	}
+}
+
+func queryCandlesCmd() *command {
+	var granularity, size int
+	return &command{
+		name:    "candles",
+		args:    "<product>",
+		short:   "Query the candles of a product: timestamp, open, high, low, close and volume",
+		minArgs: 1,
+		maxArgs: 1,
+		flags: func(fs *flag.FlagSet) {
+			fs.IntVar(&granularity, "granularity", 60, "seconds covered by a candle")
+			fs.IntVar(&size, "size", 100, "max number of the candles")
+		},
+		run: func(e *env, args []string) error {
+			cli := e.client()
+			candles, err := cli.GetCandlesInfo(args[0], granularity, size)
+			if err != nil {
+				return err
+			}
+			return e.print(candles)
+		},
+	}
+}
+
+func queryOrdersCmd() *command {
+	var product, side string
+	var closed bool
+	var paging pagingFlags
+	return &command{
+		name:    "orders",
+		args:    "<address>",
+		short:   "Query the open orders of an address, or the closed ones with --closed",
+		minArgs: 1,
+		maxArgs: 1,
+		flags: func(fs *flag.FlagSet) {
+			fs.StringVar(&product, "product", "", "product of the orders")
+			fs.StringVar(&side, "side", "BUY", "side of the orders: BUY or SELL")
+			fs.BoolVar(&closed, "closed", false, "query the closed orders")
+			paging.register(fs)
+		},
+		run: func(e *env, args []string) error {
+			cli := e.client()
+			query := cli.GetOpenOrders
+			if closed {
+				query = cli.GetClosedOrders
+			}
+			orders, err := query(args[0], product, side, paging.start, paging.endOrNow(), paging.page, paging.perPage)
+			if err != nil {
+				return err
+			}
+			return e.print(orders)
+		},
+	}
+}
+
+func queryDealsCmd() *command {
+	var product, side string
+	var paging pagingFlags
+	return &command{
+		name:    "deals",
+		args:    "<address>",
+		short:   "Query the deals of an address",
+		minArgs: 1,
+		maxArgs: 1,
+		flags: func(fs *flag.FlagSet) {
+			fs.StringVar(&product, "product", "", "product of the deals")
+			fs.StringVar(&side, "side", "BUY", "side of the deals: BUY or SELL")
+			paging.register(fs)
+		},
+		run: func(e *env, args []string) error {
+			cli := e.client()
+			deals, err := cli.GetDealsInfo(args[0], product, side, paging.start, paging.endOrNow(), paging.page, paging.perPage)
+			if err != nil {
+				return err
+			}
+			return e.print(deals)
+		},
+	}
+}
+
+// proposalRow is a row of the proposals in the table output
+type proposalRow struct {
+	ID         uint64    `json:"id"`
+	Title      string    `json:"title"`
+	Type       string    `json:"type"`
+	Status     string    `json:"status"`
+	SubmitTime time.Time `json:"submit_time"`
+}
+
+func newProposalRow(proposal types.Proposal) proposalRow {
+	return proposalRow{
+		ID:         proposal.GetProposalID(),
+		Title:      proposal.GetTitle(),
+		Type:       proposal.GetProposalType().String(),
+		Status:     proposal.GetStatus().String(),
+		SubmitTime: proposal.GetSubmitTime(),
+	}
+}
+
+func queryProposalsCmd() *command {
+	var id uint64
+	return &command{
+		name:  "proposals",
+		short: "Query the governance proposals, or a single one with --id",
+		flags: func(fs *flag.FlagSet) {
+			fs.Uint64Var(&id, "id", 0, "id of the proposal to query")
+		},
+		run: func(e *env, args []string) error {
+			cli := e.client()
+			if id != 0 {
+				proposal, err := cli.QueryProposalByID(id)
+				if err != nil {
+					return err
+				}
+				return e.printWithTable(proposal, newProposalRow(proposal))
+			}
+
+			proposals, err := cli.QueryProposals()
+			if err != nil {
+				return err
+			}
+			rows := make([]proposalRow, len(proposals))
+			for i, proposal := range proposals {
+				rows[i] = newProposalRow(proposal)
+			}
+			return e.printWithTable(proposals, rows)
+		},
+	}
+}
+
+func queryValidatorsCmd() *command {
+	return &command{
+		name:  "validators",
+		short: "Query the current validator set",
+		run: func(e *env, args []string) error {
+			cli := e.client()
+			validators, err := cli.QueryCurrentValidators()
+			if err != nil {
+				return err
+			}
+			return e.printWithTable(validators, validators.Validators)
+		},
+	}
+}
+
+// blockSummary is the table output of a block
+type blockSummary struct {
+	Height   int64     `json:"height"`
+	Time     time.Time `json:"time"`
+	Hash     string    `json:"hash"`
+	NumTxs   int64     `json:"num_txs"`
+	Proposer string    `json:"proposer"`
+}
+
+func queryBlockCmd() *command {
+	return &command{
+		name:    "block",
+		args:    "[<height>]",
+		short:   "Query a block, the latest one if the height isn't given",
+		maxArgs: 1,
+		run: func(e *env, args []string) error {
+			var height *int64
+			if len(args) == 1 {
+				h, err := strconv.ParseInt(args[0], 10, 64)
+				if err != nil {
+					return fmt.Errorf("invalid height %s: %s", args[0], err)
+				}
+				height = &h
+			}
+
+			cli := e.client()
+			block, err := cli.QueryBlock(height)
+			if err != nil {
+				return err
+			}
+			summary := blockSummary{
+				Height:   block.Block.Height,
+				Time:     block.Block.Time,
+				Hash:     block.BlockMeta.BlockID.Hash.String(),
+				NumTxs:   block.Block.NumTxs,
+				Proposer: block.Block.ProposerAddress.String(),
+			}
+			return e.printWithTable(block, summary)
+		},
+	}
+}
+
+// txSummary is the table output of a tx
+type txSummary struct {
+	Hash    string `json:"hash"`
+	Height  int64  `json:"height"`
+	Index   uint32 `json:"index"`
+	Code    uint32 `json:"code"`
+	Log     string `json:"log"`
+	GasUsed int64  `json:"gas_used"`
+}
+
+func queryTxCmd() *command {
+	return &command{
+		name:    "tx",
+		args:    "<hash>",
+		short:   "Query a tx by its hex hash",
+		minArgs: 1,
+		maxArgs: 1,
+		run: func(e *env, args []string) error {
+			hash, err := hex.DecodeString(args[0])
+			if err != nil {
+				return fmt.Errorf("invalid tx hash %s: %s", args[0], err)
+			}
+
+			cli := e.client()
+			tx, err := cli.QueryTx(hash, false)
+			if err != nil {
+				return err
+			}
+			summary := txSummary{
+				Hash:    tx.Hash.String(),
+				Height:  tx.Height,
+				Index:   tx.Index,
+				Code:    tx.TxResult.Code,
+				Log:     tx.TxResult.Log,
+				GasUsed: tx.TxResult.GasUsed,
+			}
+			return e.printWithTable(tx, summary)
+		},
+	}
+}
@@ -0,0 +1,342 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"strings"
+
+	"github.com/okex/okchain-go-sdk/client"
+	"github.com/okex/okchain-go-sdk/crypto/keys"
+	"github.com/okex/okchain-go-sdk/types"
+	"github.com/okex/okchain-go-sdk/types/msg"
+	"github.com/okex/okchain-go-sdk/types/tx"
+	"github.com/okex/okchain-go-sdk/utils"
+)
+
+func txCommands() []*command {
+	return []*command{
+		txSendCmd(),
+		txMultiSendCmd(),
+		txOrderCmd(),
+		txCancelCmd(),
+		txSignCmd(),
+		txBroadcastCmd(),
+	}
+}
+
+// txFlags are the flags of the commands signing a tx
+type txFlags struct {
+	from          string
+	password      string
+	memo          string
+	accountNumber int64
+	sequence      int64
+	generateOnly  bool
+	broadcastMode string
+}
+
+func (f *txFlags) register(fs *flag.FlagSet, withMsg bool) {
+	fs.StringVar(&f.from, "from", "", "name of the signing key, or its address with --generate-only")
+	fs.StringVar(&f.password, "password", "", "password of the signing key, read from the stdin if it's not given")
+	fs.Int64Var(&f.accountNumber, "account-number", -1, "account number of the signer, queried from the chain if it's not given")
+	fs.Int64Var(&f.sequence, "sequence", -1, "sequence of the signer, queried from the chain if it's not given")
+	if withMsg {
+		fs.StringVar(&f.memo, "memo", "", "memo of the tx")
+		fs.BoolVar(&f.generateOnly, "generate-only", false, "print the unsigned tx instead of signing and broadcasting it")
+		registerBroadcastMode(fs, &f.broadcastMode)
+	}
+}
+
+func registerBroadcastMode(fs *flag.FlagSet, mode *string) {
+	fs.StringVar(mode, "broadcast-mode", "", "broadcast mode: block, sync or async (default the one of the config, or block)")
+}
+
+// broadcastMode returns the broadcast mode of the flag, or the one of the config if it isn't given
+func (e *env) broadcastMode(mode string) string {
+	if mode == "" {
+		mode = e.cfg.BroadcastMode
+	}
+	if mode == "" {
+		mode = client.BroadcastBlock
+	}
+	return mode
+}
+
+// fromAddress returns the address of the signer. The keybase isn't required if it's given as an
+// address to generate an unsigned tx.
+func (f *txFlags) fromAddress(e *env) (types.AccAddress, error) {
+	if f.from == "" {
+		return nil, fmt.Errorf("--from is required")
+	}
+	if f.generateOnly {
+		if addr, err := types.AccAddressFromBech32(f.from); err == nil {
+			return addr, nil
+		}
+	}
+	info, err := f.signer(e)
+	if err != nil {
+		return nil, err
+	}
+	return info.GetAddress(), nil
+}
+
+func (f *txFlags) signer(e *env) (keys.Info, error) {
+	if f.from == "" {
+		return nil, fmt.Errorf("--from is required")
+	}
+	kb, err := e.keybase()
+	if err != nil {
+		return nil, err
+	}
+	return kb.Get(f.from)
+}
+
+// accountNumbers returns the account number and the sequence given by the flags, querying the
+// missing ones from the chain
+func (f *txFlags) accountNumbers(e *env, addr types.AccAddress) (accNum, seqNum uint64, err error) {
+	if f.accountNumber < 0 || f.sequence < 0 {
+		cli := e.client()
+		acc, err := cli.GetAccountInfoByAddr(addr.String())
+		if err != nil {
+			return 0, 0, fmt.Errorf("failed to query the account of the signer: %s", err)
+		}
+		accNum, seqNum = acc.GetAccountNumber(), acc.GetSequence()
+	}
+	if f.accountNumber >= 0 {
+		accNum = uint64(f.accountNumber)
+	}
+	if f.sequence >= 0 {
+		seqNum = uint64(f.sequence)
+	}
+	return accNum, seqNum, nil
+}
+
+// deliver prints the unsigned tx of the msg with --generate-only, otherwise it signs the tx and
+// broadcasts it in the broadcast mode of the flag or the config, and prints the response
+func (f *txFlags) deliver(e *env, msg types.Msg) error {
+	if f.generateOnly {
+		return printTx(e, tx.BuildUnsignedStdTx(f.memo, []types.Msg{msg}))
+	}
+
+	fromInfo, err := f.signer(e)
+	if err != nil {
+		return err
+	}
+	accNum, seqNum, err := f.accountNumbers(e, fromInfo.GetAddress())
+	if err != nil {
+		return err
+	}
+	password, err := e.password(f.password)
+	if err != nil {
+		return err
+	}
+
+	txBytes, err := tx.BuildAndSignAndEncodeStdTx(fromInfo.GetName(), password, f.memo, []types.Msg{msg}, accNum, seqNum)
+	if err != nil {
+		return err
+	}
+	cli := e.client()
+	return e.printTxResponse(cli.BroadcastTx(txBytes, e.broadcastMode(f.broadcastMode)))
+}
+
+// printTxResponse prints the response of a broadcast, also if the tx failed on the chain
+func (e *env) printTxResponse(resp types.TxResponse, err error) error {
+	if err != nil {
+		if resp.TxHash != "" {
+			_ = e.print(resp)
+		}
+		return err
+	}
+	return e.print(resp)
+}
+
+func printTx(e *env, stdTx tx.StdTx) error {
+	bz, err := tx.MsgCdc.MarshalJSONIndent(stdTx, "", "  ")
+	if err != nil {
+		return err
+	}
+	_, err = fmt.Fprintln(e.stdout, string(bz))
+	return err
+}
+
+func readTx(e *env, path string) (stdTx tx.StdTx, err error) {
+	bz, err := e.readInput(path)
+	if err != nil {
+		return
+	}
+	if err = tx.MsgCdc.UnmarshalJSON(bz, &stdTx); err != nil {
+		return stdTx, fmt.Errorf("invalid tx in %s: %s", path, err)
+	}
+	return
+}
+
+func txSendCmd() *command {
+	var f txFlags
+	return &command{
+		name:    "send",
+		args:    "<to-address> <coins>",
+		short:   "Transfer coins, e.g. 10.5okt,1xxb",
+		minArgs: 2,
+		maxArgs: 2,
+		flags:   func(fs *flag.FlagSet) { f.register(fs, true) },
+		run: func(e *env, args []string) error {
+			from, err := f.fromAddress(e)
+			if err != nil {
+				return err
+			}
+			to, err := types.AccAddressFromBech32(args[0])
+			if err != nil {
+				return fmt.Errorf("invalid receiver address %s: %s", args[0], err)
+			}
+			coins, err := utils.ParseCoins(args[1])
+			if err != nil {
+				return err
+			}
+
+			return f.deliver(e, msg.NewMsgTokenSend(from, to, coins))
+		},
+	}
+}
+
+func txMultiSendCmd() *command {
+	var f txFlags
+	return &command{
+		name:    "multisend",
+		args:    "<transfers>",
+		short:   `Transfer coins to several receivers, e.g. '[{"to":"okchain1...","amount":"1okt"}]', or @file to read them from a file`,
+		minArgs: 1,
+		maxArgs: 1,
+		flags:   func(fs *flag.FlagSet) { f.register(fs, true) },
+		run: func(e *env, args []string) error {
+			from, err := f.fromAddress(e)
+			if err != nil {
+				return err
+			}
+			transfersStr := args[0]
+			if strings.HasPrefix(transfersStr, "@") {
+				bz, err := e.readInput(transfersStr[1:])
+				if err != nil {
+					return err
+				}
+				transfersStr = string(bz)
+			}
+			transfers, err := utils.StrToTransfers(transfersStr)
+			if err != nil {
+				return fmt.Errorf("invalid transfers: %s", err)
+			}
+
+			return f.deliver(e, msg.NewMsgMultiSend(from, transfers))
+		},
+	}
+}
+
+func txOrderCmd() *command {
+	var f txFlags
+	return &command{
+		name:    "order",
+		args:    "<product> <BUY|SELL> <price> <quantity>",
+		short:   "Place an order on the dex",
+		minArgs: 4,
+		maxArgs: 4,
+		flags:   func(fs *flag.FlagSet) { f.register(fs, true) },
+		run: func(e *env, args []string) error {
+			from, err := f.fromAddress(e)
+			if err != nil {
+				return err
+			}
+			items := []msg.OrderItem{msg.NewOrderItem(args[0], args[1], args[2], args[3])}
+			if !f.generateOnly {
+				cli := e.client()
+				if items, err = cli.ValidateOrderItems(items); err != nil {
+					return err
+				}
+			}
+
+			return f.deliver(e, msg.NewMsgNewOrders(from, items))
+		},
+	}
+}
+
+func txCancelCmd() *command {
+	var f txFlags
+	return &command{
+		name:    "cancel",
+		args:    "<order-id>...",
+		short:   "Cancel orders on the dex",
+		minArgs: 1,
+		maxArgs: -1,
+		flags:   func(fs *flag.FlagSet) { f.register(fs, true) },
+		run: func(e *env, args []string) error {
+			from, err := f.fromAddress(e)
+			if err != nil {
+				return err
+			}
+
+			return f.deliver(e, msg.NewMsgCancelOrders(from, args))
+		},
+	}
+}
+
+func txSignCmd() *command {
+	var f txFlags
+	return &command{
+		name:    "sign",
+		args:    "<tx-file>",
+		short:   "Sign a tx generated by --generate-only, the file is read from the stdin if it's -",
+		minArgs: 1,
+		maxArgs: 1,
+		flags:   func(fs *flag.FlagSet) { f.register(fs, false) },
+		run: func(e *env, args []string) error {
+			stdTx, err := readTx(e, args[0])
+			if err != nil {
+				return err
+			}
+			fromInfo, err := f.signer(e)
+			if err != nil {
+				return err
+			}
+			accNum, seqNum, err := f.accountNumbers(e, fromInfo.GetAddress())
+			if err != nil {
+				return err
+			}
+			password, err := e.password(f.password)
+			if err != nil {
+				return err
+			}
+
+			signed, err := tx.SignStdTx(fromInfo.GetName(), password, stdTx, accNum, seqNum)
+			if err != nil {
+				return err
+			}
+			return printTx(e, signed)
+		},
+	}
+}
+
+func txBroadcastCmd() *command {
+	var mode string
+	return &command{
+		name:    "broadcast",
+		args:    "<tx-file>",
+		short:   "Broadcast a signed tx, the file is read from the stdin if it's -",
+		minArgs: 1,
+		maxArgs: 1,
+		flags:   func(fs *flag.FlagSet) { registerBroadcastMode(fs, &mode) },
+		run: func(e *env, args []string) error {
+			stdTx, err := readTx(e, args[0])
+			if err != nil {
+				return err
+			}
+			if len(stdTx.Signatures) == 0 {
+				return fmt.Errorf("the tx in %s isn't signed", args[0])
+			}
+			txBytes, err := tx.MsgCdc.MarshalBinaryLengthPrefixed(stdTx)
+			if err != nil {
+				return err
+			}
+
+			cli := e.client()
+			return e.printTxResponse(cli.BroadcastTx(txBytes, e.broadcastMode(mode)))
+		},
+	}
+}
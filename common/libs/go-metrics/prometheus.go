@@ -0,0 +1,125 @@
+package metrics
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"net/http"
+	"sort"
+	"strings"
+	"time"
+)
+
+// PrometheusConfig provides a container with configuration parameters for
+// the Prometheus text-format exporter
+type PrometheusConfig struct {
+	Registry     Registry      // Registry to be exported
+	DurationUnit time.Duration // Time conversion unit for durations
+	Prefix       string        // Prefix to be prepended to metric names
+	Percentiles  []float64     // Percentiles to export from timers and histograms
+}
+
+// Prometheus writes the metrics in r to w once in the Prometheus text
+// exposition format, prepending metric names with prefix.
+func Prometheus(r Registry, prefix string, w io.Writer) error {
+	return PrometheusWithConfig(PrometheusConfig{
+		Registry:     r,
+		DurationUnit: time.Second,
+		Prefix:       prefix,
+		Percentiles:  []float64{0.5, 0.75, 0.95, 0.99, 0.999},
+	}, w)
+}
+
+// PrometheusWithConfig is an exporter function just like Prometheus,
+// but it takes a PrometheusConfig instead.
+func PrometheusWithConfig(c PrometheusConfig, w io.Writer) error {
+	return prometheus(&c, w)
+}
+
+// PrometheusHandler returns an http.Handler serving the metrics in the
+// configured registry for a Prometheus server to scrape.
+func PrometheusHandler(c PrometheusConfig) http.Handler {
+	return http.HandlerFunc(func(rw http.ResponseWriter, _ *http.Request) {
+		rw.Header().Set("Content-Type", "text/plain; version=0.0.4")
+		if err := prometheus(&c, rw); err != nil {
+			http.Error(rw, err.Error(), http.StatusInternalServerError)
+		}
+	})
+}
+
+func prometheus(c *PrometheusConfig, out io.Writer) error {
+	du := float64(c.DurationUnit)
+	if du == 0 {
+		du = float64(time.Second)
+	}
+
+	var namedMetrics namedMetricSlice
+	c.Registry.Each(func(name string, i interface{}) {
+		namedMetrics = append(namedMetrics, namedMetric{name, i})
+	})
+	sort.Sort(namedMetrics)
+
+	w := bufio.NewWriter(out)
+	for _, nm := range namedMetrics {
+		name := prometheusName(c.Prefix, nm.name)
+		switch metric := nm.m.(type) {
+		case Counter:
+			fmt.Fprintf(w, "# TYPE %s counter\n", name)
+			fmt.Fprintf(w, "%s %d\n", name, metric.Count())
+		case Gauge:
+			fmt.Fprintf(w, "# TYPE %s gauge\n", name)
+			fmt.Fprintf(w, "%s %d\n", name, metric.Value())
+		case GaugeFloat64:
+			fmt.Fprintf(w, "# TYPE %s gauge\n", name)
+			fmt.Fprintf(w, "%s %f\n", name, metric.Value())
+		case Histogram:
+			h := metric.Snapshot()
+			ps := h.Percentiles(c.Percentiles)
+			fmt.Fprintf(w, "# TYPE %s summary\n", name)
+			for psIdx, psKey := range c.Percentiles {
+				fmt.Fprintf(w, "%s{quantile=\"%s\"} %.2f\n", name, prometheusFloat(psKey), ps[psIdx])
+			}
+			fmt.Fprintf(w, "%s_sum %d\n", name, h.Sum())
+			fmt.Fprintf(w, "%s_count %d\n", name, h.Count())
+		case Meter:
+			m := metric.Snapshot()
+			fmt.Fprintf(w, "# TYPE %s_total counter\n", name)
+			fmt.Fprintf(w, "%s_total %d\n", name, m.Count())
+			fmt.Fprintf(w, "# TYPE %s_rate gauge\n", name)
+			fmt.Fprintf(w, "%s_rate{window=\"1m\"} %.2f\n", name, m.Rate1())
+			fmt.Fprintf(w, "%s_rate{window=\"5m\"} %.2f\n", name, m.Rate5())
+			fmt.Fprintf(w, "%s_rate{window=\"15m\"} %.2f\n", name, m.Rate15())
+			fmt.Fprintf(w, "%s_rate{window=\"mean\"} %.2f\n", name, m.RateMean())
+		case Timer:
+			t := metric.Snapshot()
+			ps := t.Percentiles(c.Percentiles)
+			fmt.Fprintf(w, "# TYPE %s summary\n", name)
+			for psIdx, psKey := range c.Percentiles {
+				fmt.Fprintf(w, "%s{quantile=\"%s\"} %f\n", name, prometheusFloat(psKey), ps[psIdx]/du)
+			}
+			fmt.Fprintf(w, "%s_sum %f\n", name, float64(t.Sum())/du)
+			fmt.Fprintf(w, "%s_count %d\n", name, t.Count())
+		}
+	}
+	return w.Flush()
+}
+
+// prometheusName joins prefix and name and replaces every character which is
+// not allowed in a Prometheus metric name with an underscore.
+func prometheusName(prefix, name string) string {
+	if prefix != "" {
+		name = prefix + "_" + name
+	}
+	return strings.Map(func(r rune) rune {
+		switch {
+		case r >= 'a' && r <= 'z', r >= 'A' && r <= 'Z', r >= '0' && r <= '9', r == '_', r == ':':
+			return r
+		default:
+			return '_'
+		}
+	}, name)
+}
+
+func prometheusFloat(f float64) string {
+	return strings.TrimRight(strings.TrimRight(fmt.Sprintf("%f", f), "0"), ".")
+}
@@ -0,0 +1,143 @@
+package log
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Level is the severity of a log entry
+type Level int
+
+const (
+	LevelDebug Level = iota
+	LevelInfo
+	LevelWarn
+	LevelError
+	// LevelNone disables all the log entries
+	LevelNone
+)
+
+var levelNames = map[Level]string{
+	LevelDebug: "debug",
+	LevelInfo:  "info",
+	LevelWarn:  "warn",
+	LevelError: "error",
+}
+
+// String implements the Stringer interface
+func (l Level) String() string {
+	return levelNames[l]
+}
+
+// Logger is the structured logger used inside the sdk. The keyvals are the
+// fields of the entry given as alternating keys and values.
+type Logger interface {
+	Debug(msg string, keyvals ...interface{})
+	Info(msg string, keyvals ...interface{})
+	Warn(msg string, keyvals ...interface{})
+	Error(msg string, keyvals ...interface{})
+
+	// With returns a logger which adds the keyvals to every entry
+	With(keyvals ...interface{}) Logger
+}
+
+type nopLogger struct{}
+
+var _ Logger = nopLogger{}
+
+// NewNopLogger returns a logger that discards every entry. It's the default logger of the sdk.
+func NewNopLogger() Logger { return nopLogger{} }
+
+func (nopLogger) Debug(string, ...interface{}) {}
+func (nopLogger) Info(string, ...interface{})  {}
+func (nopLogger) Warn(string, ...interface{})  {}
+func (nopLogger) Error(string, ...interface{}) {}
+func (l nopLogger) With(...interface{}) Logger { return l }
+
+// writerLogger writes the entries in logfmt to an io.Writer
+type writerLogger struct {
+	mtx     *sync.Mutex
+	w       io.Writer
+	level   Level
+	keyvals []interface{}
+}
+
+var _ Logger = writerLogger{}
+
+// NewLogger returns a logger writing the entries with a level not lower than the given one to w
+// in the logfmt format, e.g.
+//
+//	ts=2019-11-01T08:00:00Z level=info msg="query done" path=custom/token/tokens
+func NewLogger(w io.Writer, level Level) Logger {
+	return writerLogger{
+		mtx:   new(sync.Mutex),
+		w:     w,
+		level: level,
+	}
+}
+
+func (l writerLogger) Debug(msg string, keyvals ...interface{}) { l.log(LevelDebug, msg, keyvals) }
+func (l writerLogger) Info(msg string, keyvals ...interface{})  { l.log(LevelInfo, msg, keyvals) }
+func (l writerLogger) Warn(msg string, keyvals ...interface{})  { l.log(LevelWarn, msg, keyvals) }
+func (l writerLogger) Error(msg string, keyvals ...interface{}) { l.log(LevelError, msg, keyvals) }
+
+func (l writerLogger) With(keyvals ...interface{}) Logger {
+	kvs := make([]interface{}, 0, len(l.keyvals)+len(keyvals))
+	kvs = append(kvs, l.keyvals...)
+	l.keyvals = append(kvs, keyvals...)
+	return l
+}
+
+func (l writerLogger) log(level Level, msg string, keyvals []interface{}) {
+	if level < l.level {
+		return
+	}
+
+	var sb strings.Builder
+	sb.WriteString("ts=")
+	sb.WriteString(time.Now().UTC().Format(time.RFC3339))
+	sb.WriteString(" level=")
+	sb.WriteString(level.String())
+	sb.WriteString(" msg=")
+	sb.WriteString(formatValue(msg))
+	writeKeyvals(&sb, l.keyvals)
+	writeKeyvals(&sb, keyvals)
+	sb.WriteString("\n")
+
+	l.mtx.Lock()
+	defer l.mtx.Unlock()
+	_, _ = io.WriteString(l.w, sb.String())
+}
+
+func writeKeyvals(sb *strings.Builder, keyvals []interface{}) {
+	for i := 0; i < len(keyvals); i += 2 {
+		var value interface{} = "(MISSING)"
+		if i+1 < len(keyvals) {
+			value = keyvals[i+1]
+		}
+		sb.WriteString(" ")
+		sb.WriteString(fmt.Sprint(keyvals[i]))
+		sb.WriteString("=")
+		sb.WriteString(formatValue(value))
+	}
+}
+
+func formatValue(value interface{}) string {
+	var str string
+	switch v := value.(type) {
+	case error:
+		str = v.Error()
+	case fmt.Stringer:
+		str = v.String()
+	default:
+		str = fmt.Sprint(v)
+	}
+
+	if str == "" || strings.ContainsAny(str, " \t\n\"=") {
+		return fmt.Sprintf("%q", str)
+	}
+	return str
+}
@@ -0,0 +1,37 @@
+package log
+
+import (
+	"bytes"
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestLogger(t *testing.T) {
+	var buf bytes.Buffer
+	logger := NewLogger(&buf, LevelInfo).With("module", "okclient")
+
+	logger.Debug("filtered out")
+	if buf.Len() != 0 {
+		t.Fatalf("debug entry should be filtered: %s", buf.String())
+	}
+
+	logger.Error("query failed", "path", "custom/token/tokens", "err", errors.New("connection refused"))
+	line := buf.String()
+	for _, expected := range []string{
+		"level=error",
+		`msg="query failed"`,
+		"module=okclient",
+		"path=custom/token/tokens",
+		`err="connection refused"`,
+	} {
+		if !strings.Contains(line, expected) {
+			t.Errorf("%q not found in %q", expected, line)
+		}
+	}
+}
+
+func TestNopLogger(t *testing.T) {
+	logger := NewNopLogger().With("module", "okclient")
+	logger.Error("nothing happens")
+}
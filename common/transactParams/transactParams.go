@@ -1,72 +1,129 @@
 package transactParams
 
 import (
+	"errors"
 	"fmt"
+	"github.com/okex/okchain-go-sdk/common"
 	"github.com/okex/okchain-go-sdk/crypto/keys"
+	"github.com/okex/okchain-go-sdk/types"
+	"github.com/okex/okchain-go-sdk/types/msg"
+	"math/big"
 	"strings"
 )
 
-func IsValidSendParams(fromInfo keys.Info, passWd, toAddr string) bool {
-	if fromInfo == nil {
-		fmt.Println("input invalid keys info")
-		return false
-	}
-	if len(passWd) == 0 {
-		fmt.Println("no password input")
-		return false
+func CheckSendParams(fromInfo keys.Info, passWd, toAddr string) error {
+	return CheckSendParamsWithPrefix(fromInfo, passWd, toAddr, types.GetConfig().GetBech32AccountAddrPrefix())
+}
+
+// CheckSendParamsWithPrefix checks the params of a transfer whose receiver is encoded by the prefix
+// of the account addresses of a network
+func CheckSendParamsWithPrefix(fromInfo keys.Info, passWd, toAddr, addrPrefix string) error {
+	if err := checkKeyParams(fromInfo, passWd); err != nil {
+		return err
 	}
-	if len(toAddr) != 46 || !strings.HasPrefix(toAddr, "okchain") {
-		fmt.Println("input invalid receiver address")
-		return false
+	if err := common.CheckAccAddrWithPrefix(toAddr, addrPrefix); err != nil {
+		return fmt.Errorf("invalid receiver address: %s", err)
 	}
-	return true
+	return nil
 }
 
-func IsValidNewOrderParams(fromInfo keys.Info, passWd, product, side string) bool {
-	if fromInfo == nil {
-		fmt.Println("input invalid keys info")
-		return false
+func CheckMultiSendParams(fromInfo keys.Info, passWd string, transfers []types.TransferUnit) error {
+	if err := checkKeyParams(fromInfo, passWd); err != nil {
+		return err
 	}
-	if len(passWd) == 0 {
-		fmt.Println("no password input")
-		return false
+	if len(transfers) == 0 {
+		return errors.New("no transfer input")
+	}
+	for _, transfer := range transfers {
+		if transfer.To.Empty() {
+			return errors.New("empty receiver address")
+		}
+		if len(transfer.Coins) == 0 || !transfer.Coins.IsValid() {
+			return fmt.Errorf("invalid coins to transfer to %s: %s", transfer.To, transfer.Coins)
+		}
+	}
+	return nil
+}
+
+func CheckNewOrderParams(fromInfo keys.Info, passWd, product, side string) error {
+	if err := checkKeyParams(fromInfo, passWd); err != nil {
+		return err
 	}
 	if len(product) == 0 {
-		fmt.Println("no product input")
-		return false
+		return errors.New("no product input")
 	}
-	if side != "BUY" && side != "SELL" {
-		fmt.Println("side can only be \"BUY\" or \"SELL\"")
-		return false
+	if !common.IsValidSide(side) {
+		return fmt.Errorf("side can only be \"BUY\" or \"SELL\" but got %q", side)
 	}
+	return nil
+}
+
+// CheckOrderItemWithTokenPair checks the price and the quantity of an order against the precision
+// rules of its product, which the chain rejects the orders breaking
+func CheckOrderItemWithTokenPair(item msg.OrderItem, tokenPair types.TokenPair) error {
+	if !item.Price.IsPositive() {
+		return fmt.Errorf("the price of the order of %s has to be positive but got %s", item.Product, item.Price)
+	}
+	if err := checkAccuracyOfStr(item.Price.String(), int(tokenPair.MaxPriceDigit)); err != nil {
+		return fmt.Errorf("invalid price of the order of %s: %s", item.Product, err)
+	}
+	if err := checkAccuracyOfStr(item.Quantity.String(), int(tokenPair.MaxQuantityDigit)); err != nil {
+		return fmt.Errorf("invalid quantity of the order of %s: %s", item.Product, err)
+	}
+	if item.Quantity.LT(tokenPair.MinQuantity) || !item.Quantity.IsPositive() {
+		return fmt.Errorf("the quantity of the order of %s has to be at least %s but got %s", item.Product, tokenPair.MinQuantity, item.Quantity)
+	}
+	return nil
+}
 
-	return true
+// RoundOrderItemWithTokenPair rounds the price and the quantity of an order to the digits of its
+// product without exceeding the limit of the order: the price of a buy order is rounded down and
+// the one of a sell order up, while the quantity is rounded down. The rounded order is checked
+// then, e.g. against the minimum quantity.
+func RoundOrderItemWithTokenPair(item msg.OrderItem, tokenPair types.TokenPair) (msg.OrderItem, error) {
+	item.Price = roundDecToDigits(item.Price, tokenPair.MaxPriceDigit, item.Side == "SELL")
+	item.Quantity = roundDecToDigits(item.Quantity, tokenPair.MaxQuantityDigit, false)
+	return item, CheckOrderItemWithTokenPair(item, tokenPair)
 }
 
-func IsValidCancelOrderParams(fromInfo keys.Info, passWd string) bool {
+func CheckCancelOrderParams(fromInfo keys.Info, passWd string) error {
+	return checkKeyParams(fromInfo, passWd)
+}
+
+func checkKeyParams(fromInfo keys.Info, passWd string) error {
 	if fromInfo == nil {
-		fmt.Println("input invalid keys info")
-		return false
+		return errors.New("input invalid keys info")
 	}
 	if len(passWd) == 0 {
-		fmt.Println("no password input")
-		return false
+		return errors.New("no password input")
 	}
-	return true
+	return nil
 }
 
-func checkAccuracyOfStr(num string, accuracy int) bool {
+func checkAccuracyOfStr(num string, accuracy int) error {
 	num = strings.TrimSpace(num)
 	strs := strings.Split(num, ".")
 	if len(strs) > 2 || len(strs) == 0 {
-		return false
+		return fmt.Errorf("invalid number %q", num)
 	} else if len(strs) == 2 {
 		for i, v := range strs[1] {
 			if i > accuracy-1 && v != '0' {
-				fmt.Printf("the accuracy can't be larger than %d\n", accuracy)
-				return false
+				return fmt.Errorf("the accuracy of %s can't be larger than %d", num, accuracy)
 			}
 		}
 	}
-	return true
+	return nil
+}
+
+// roundDecToDigits rounds a non-negative decimal down or up to the digits
+func roundDecToDigits(d types.Dec, digits int64, up bool) types.Dec {
+	if digits < 0 || digits >= types.Precision || d.IsNil() {
+		return d
+	}
+	multiplier := new(big.Int).Exp(big.NewInt(10), big.NewInt(types.Precision-digits), nil)
+	quo, rem := new(big.Int).QuoRem(d.Int, multiplier, new(big.Int))
+	if up && rem.Sign() > 0 {
+		quo.Add(quo, big.NewInt(1))
+	}
+	return types.NewDecFromBigIntWithPrec(quo, digits)
 }
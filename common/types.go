@@ -3,7 +3,7 @@ package common
 type BaseResponse struct {
 	Code      int         `json:"code"`
 	Msg       string      `json:"msg"`
-	detail_msg string      `json:"detail_msg"`
+	DetailMsg string      `json:"detail_msg"`
 	Data      interface{} `json:"data"`
 }
 
@@ -21,6 +21,6 @@ type ListDataRes struct {
 type ListResponse struct {
 	Code      int         `json:"code"`
 	Msg       string      `json:"msg"`
-	detail_msg string      `json:"detail_msg"`
+	DetailMsg string      `json:"detail_msg"`
 	Data      ListDataRes `json:"data"`
-}
\ No newline at end of file
+}
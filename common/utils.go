@@ -3,7 +3,9 @@ package common
 import (
 	"errors"
 	"fmt"
-	"strings"
+
+	"github.com/okex/okchain-go-sdk/crypto/btcsuite/btcutil/bech32"
+	"github.com/okex/okchain-go-sdk/types"
 )
 
 const (
@@ -12,12 +14,31 @@ const (
 	OrderItemLimit = 200
 )
 
-func IsValidAccaddr(addr string) bool {
-	if len(addr) != 46 || !strings.HasPrefix(addr, "okchain") {
-		fmt.Println("address inputed is not valid")
-		return false
+// CheckAccAddr returns an error describing why the account address is invalid. It must be a Bech32
+// string with a valid checksum, whose prefix is the one of the account addresses of the config and
+// whose payload is an address of 20 bytes.
+func CheckAccAddr(addr string) error {
+	return CheckAccAddrWithPrefix(addr, types.GetConfig().GetBech32AccountAddrPrefix())
+}
+
+// CheckAccAddrWithPrefix checks the account address like CheckAccAddr against the prefix of the
+// account addresses of a network
+func CheckAccAddrWithPrefix(addr, prefix string) error {
+	hrp, data, err := bech32.Decode(addr)
+	if err != nil {
+		return fmt.Errorf("invalid address %q: %s", addr, err)
 	}
-	return true
+	if hrp != prefix {
+		return fmt.Errorf("invalid address %q: the prefix should be %q but got %q", addr, prefix, hrp)
+	}
+	bz, err := bech32.ConvertBits(data, 5, 8, false)
+	if err != nil {
+		return fmt.Errorf("invalid address %q: %s", addr, err)
+	}
+	if len(bz) != types.AddrLen {
+		return fmt.Errorf("invalid address %q: the length should be %d bytes but got %d", addr, types.AddrLen, len(bz))
+	}
+	return nil
 }
 
 func IsValidSide(side string) bool {
@@ -0,0 +1,32 @@
+package common
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/tendermint/tendermint/libs/bech32"
+)
+
+func TestCheckAccAddr(t *testing.T) {
+	addr := "okchain1g7c3nvac7mjgn2m9mqllgat8wwd3aptdqket5k"
+	if err := CheckAccAddr(addr); err != nil {
+		t.Fatal(err)
+	}
+
+	validatorAddr, _ := bech32.ConvertAndEncode("okchainvaloper", make([]byte, 20))
+	shortAddr, _ := bech32.ConvertAndEncode("okchain", make([]byte, 19))
+	for _, c := range []struct {
+		addr, reason string
+	}{
+		{"okchain1g7c3nvac7mjgn2m9mqllgat8wwd3aptdqket5q", "checksum"},
+		{strings.Replace(addr, "okchain", "cosmos0", 1), "checksum"},
+		{validatorAddr, "prefix"},
+		{shortAddr, "length"},
+		{"", "length"},
+	} {
+		err := CheckAccAddr(c.addr)
+		if err == nil || !strings.Contains(err.Error(), c.reason) {
+			t.Fatalf("expected an invalid %s of %q but got %v", c.reason, c.addr, err)
+		}
+	}
+}
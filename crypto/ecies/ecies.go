@@ -0,0 +1,48 @@
+// Package ecies encrypts data to the secp256k1 public keys of the accounts by the ECIES scheme of
+// btcec: an ECDH secret with an ephemeral key, AES-256-CBC and HMAC-SHA-256, which is byte
+// compatible with pyelliptic. The ciphertext is 134 bytes longer than the data at least.
+package ecies
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/okex/okchain-go-sdk/crypto/btcsuite/btcd/btcec"
+	"github.com/okex/okchain-go-sdk/types"
+	"github.com/tendermint/tendermint/crypto"
+	"github.com/tendermint/tendermint/crypto/secp256k1"
+)
+
+// Encrypt encrypts the data to the public key, so that only the owner of its private key can
+// decrypt it
+func Encrypt(pubKey crypto.PubKey, plaintext []byte) ([]byte, error) {
+	secpPub, ok := pubKey.(secp256k1.PubKeySecp256k1)
+	if !ok {
+		return nil, fmt.Errorf("only secp256k1 public keys are supported, got %T", pubKey)
+	}
+	pub, err := btcec.ParsePubKey(secpPub[:], btcec.S256())
+	if err != nil {
+		return nil, err
+	}
+	return btcec.Encrypt(pub, plaintext)
+}
+
+// EncryptToBech32PubKey encrypts the data to the bech32 public key like okchainpub1...
+func EncryptToBech32PubKey(bech32PubKey string, plaintext []byte) ([]byte, error) {
+	pubKey, err := types.GetAccPubKeyBech32(bech32PubKey)
+	if err != nil {
+		return nil, fmt.Errorf("invalid bech32 public key %s: %s", bech32PubKey, err)
+	}
+	return Encrypt(pubKey, plaintext)
+}
+
+// Decrypt decrypts the data encrypted to the public key of the private key. It fails if the data
+// was encrypted to another key or was modified.
+func Decrypt(privKey crypto.PrivKey, ciphertext []byte) ([]byte, error) {
+	secpPriv, ok := privKey.(secp256k1.PrivKeySecp256k1)
+	if !ok {
+		return nil, errors.New("only local secp256k1 private keys can decrypt")
+	}
+	priv, _ := btcec.PrivKeyFromBytes(btcec.S256(), secpPriv[:])
+	return btcec.Decrypt(priv, ciphertext)
+}
@@ -0,0 +1,61 @@
+package ecies_test
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/okex/okchain-go-sdk/crypto/ecies"
+	"github.com/okex/okchain-go-sdk/crypto/keys"
+	"github.com/okex/okchain-go-sdk/types"
+)
+
+const (
+	passWd   = "12345678"
+	mnemonic = "total lottery arena when pudding best candy until army spoil drill pool"
+)
+
+func TestEncryptDecrypt(t *testing.T) {
+	kb := keys.NewInMemory()
+	alice, err := kb.CreateAccount("alice", mnemonic, "", passWd, 0, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := kb.CreateAccount("bob", mnemonic, "", passWd, 0, 1); err != nil {
+		t.Fatal(err)
+	}
+
+	plaintext := []byte("settle 100okt to the account 42")
+	bech32PubKey, err := types.Bech32ifyAccPub(alice.GetPubKey())
+	if err != nil {
+		t.Fatal(err)
+	}
+	ciphertext, err := ecies.EncryptToBech32PubKey(bech32PubKey, plaintext)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if bytes.Contains(ciphertext, plaintext) {
+		t.Fatal("the plaintext isn't encrypted")
+	}
+
+	decrypted, err := kb.Decrypt("alice", passWd, ciphertext)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(decrypted, plaintext) {
+		t.Fatalf("unexpected decrypted data: %s", decrypted)
+	}
+
+	if _, err := kb.Decrypt("bob", passWd, ciphertext); err == nil {
+		t.Fatal("the data is decrypted by another key")
+	}
+	if _, err := kb.Decrypt("alice", "wrong password", ciphertext); err == nil {
+		t.Fatal("the data is decrypted with a wrong password")
+	}
+	ciphertext[len(ciphertext)/2]++
+	if _, err := kb.Decrypt("alice", passWd, ciphertext); err == nil {
+		t.Fatal("modified data is decrypted")
+	}
+	if _, err := ecies.EncryptToBech32PubKey("okchainpub1invalid", plaintext); err == nil {
+		t.Fatal("data is encrypted to an invalid public key")
+	}
+}
@@ -11,6 +11,7 @@ import (
 	"strings"
 
 	"golang.org/x/crypto/pbkdf2"
+	"golang.org/x/text/unicode/norm"
 )
 
 // Some bitwise operands for working with big.Ints
@@ -38,6 +39,10 @@ func NewEntropy(bitSize int) ([]byte, error) {
 // the given entropy.
 // If the provide entropy is invalid, an error will be returned.
 func NewMnemonic(entropy []byte) (string, error) {
+	return newMnemonic(entropy, WordList, " ")
+}
+
+func newMnemonic(entropy []byte, wordList []string, separator string) (string, error) {
 	// Compute some lengths for convenience
 	entropyBitLength := len(entropy) * 8
 	checksumBitLength := entropyBitLength / 32
@@ -74,21 +79,32 @@ func NewMnemonic(entropy []byte) (string, error) {
 		wordBytes := padByteSlice(word.Bytes(), 2)
 
 		// Convert bytes to an index and add that word to the list
-		words[i] = WordList[binary.BigEndian.Uint16(wordBytes)]
+		words[i] = wordList[binary.BigEndian.Uint16(wordBytes)]
 	}
 
-	return strings.Join(words, " "), nil
+	return strings.Join(words, separator), nil
 }
 
 // MnemonicToByteArray takes a mnemonic string and turns it into a byte array
 // suitable for creating another mnemonic.
+// The mnemonic may be in any language of a registered wordlist.
 // An error is returned if the mnemonic is invalid.
 func MnemonicToByteArray(mnemonic string) ([]byte, error) {
 	if IsMnemonicValid(mnemonic) == false {
 		return nil, fmt.Errorf("Invalid mnemonic")
 	}
-	mnemonicSlice := strings.Split(mnemonic, " ")
+	language, err := DetectLanguage(mnemonic)
+	if err != nil {
+		return nil, err
+	}
+	list, err := getWordList(language)
+	if err != nil {
+		return nil, err
+	}
+	return mnemonicToByteArray(strings.Fields(NormalizeMnemonic(mnemonic)), list.reverse)
+}
 
+func mnemonicToByteArray(mnemonicSlice []string, reverseWordMap map[string]int) ([]byte, error) {
 	bitSize := len(mnemonicSlice) * 11
 	err := validateEntropyWithChecksumBitSize(bitSize)
 	if err != nil {
@@ -99,7 +115,7 @@ func MnemonicToByteArray(mnemonic string) ([]byte, error) {
 	b := big.NewInt(0)
 	modulo := big.NewInt(2048)
 	for _, v := range mnemonicSlice {
-		index, found := ReverseWordMap[v]
+		index, found := reverseWordMap[v]
 		if found == false {
 			return nil, fmt.Errorf("Word `%v` not found in reverse map", v)
 		}
@@ -156,20 +172,40 @@ func MnemonicToByteArray(mnemonic string) ([]byte, error) {
 	return hex, nil
 }
 
+// EntropyFromMnemonic takes a mnemonic string and returns the entropy it
+// encodes, without the checksum.
+// An error is returned if the mnemonic or its checksum is invalid.
+func EntropyFromMnemonic(mnemonic string) ([]byte, error) {
+	entropyWithChecksum, err := MnemonicToByteArray(mnemonic)
+	if err != nil {
+		return nil, err
+	}
+
+	bitSize := len(strings.Fields(NormalizeMnemonic(mnemonic))) * 11
+	checksumSize := bitSize % 32
+	entropy := new(big.Int).SetBytes(entropyWithChecksum)
+	entropy.Rsh(entropy, uint(checksumSize))
+	return padByteSlice(entropy.Bytes(), (bitSize-checksumSize)/8), nil
+}
+
 // NewSeedWithErrorChecking creates a hashed seed output given the mnemonic string and a password.
 // An error is returned if the mnemonic is not convertible to a byte array.
+// The words of the mnemonic are separated by single spaces before hashing,
+// so that a mnemonic typed with other spaces gives the same seed.
 func NewSeedWithErrorChecking(mnemonic string, password string) ([]byte, error) {
 	_, err := MnemonicToByteArray(mnemonic)
 	if err != nil {
 		return nil, err
 	}
-	return NewSeed(mnemonic, password), nil
+	return NewSeed(NormalizeMnemonic(mnemonic), password), nil
 }
 
 // NewSeed creates a hashed seed output given a provided string and password.
+// Both are normalized in NFKD as the spec requires, so that the seeds of the
+// mnemonics and passwords with accents or in CJK match the ones of other wallets.
 // No checking is performed to validate that the string provided is a valid mnemonic.
 func NewSeed(mnemonic string, password string) []byte {
-	return pbkdf2.Key([]byte(mnemonic), []byte("mnemonic"+password), 2048, 64, sha512.New)
+	return pbkdf2.Key([]byte(norm.NFKD.String(mnemonic)), []byte(norm.NFKD.String("mnemonic"+password)), 2048, 64, sha512.New)
 }
 
 // Appends to data the first (len(data) / 32)bits of the result of sha256(data)
@@ -222,10 +258,11 @@ func validateEntropyWithChecksumBitSize(bitSize int) error {
 
 // IsMnemonicValid attempts to verify that the provided mnemonic is valid.
 // Validity is determined by both the number of words being appropriate,
-// and that all the words in the mnemonic are present in the word list.
+// and that all the words in the mnemonic are present in the word list
+// of one of the registered languages.
 func IsMnemonicValid(mnemonic string) bool {
 	// Create a list of all the words in the mnemonic sentence
-	words := strings.Fields(mnemonic)
+	words := strings.Fields(NormalizeMnemonic(mnemonic))
 
 	//Get num of words
 	numOfWords := len(words)
@@ -235,21 +272,7 @@ func IsMnemonicValid(mnemonic string) bool {
 		return false
 	}
 
-	// Check if all words belong in the wordlist
-	for i := 0; i < numOfWords; i++ {
-		if !contains(WordList, words[i]) {
-			return false
-		}
-	}
-
-	return true
-}
-
-func contains(s []string, e string) bool {
-	for _, a := range s {
-		if a == e {
-			return true
-		}
-	}
-	return false
+	// Check if all words belong in the wordlist of a language
+	_, err := DetectLanguage(mnemonic)
+	return err == nil
 }
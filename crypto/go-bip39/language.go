@@ -0,0 +1,173 @@
+package bip39
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+
+	"golang.org/x/text/unicode/norm"
+)
+
+// The languages of the wordlists in the BIP 39 spec, named as their files
+const (
+	English            = "english"
+	ChineseSimplified  = "chinese_simplified"
+	ChineseTraditional = "chinese_traditional"
+	Japanese           = "japanese"
+	Korean             = "korean"
+	Spanish            = "spanish"
+	French             = "french"
+	Italian            = "italian"
+)
+
+// languages is the order in which the languages are tried to detect the one of a mnemonic
+var languages = []string{English, ChineseSimplified, ChineseTraditional, Japanese, Korean, Spanish, French, Italian}
+
+// ideographicSpace separates the words of a Japanese mnemonic, as the spec recommends
+const ideographicSpace = "\u3000"
+
+type wordList struct {
+	words   []string
+	reverse map[string]int
+}
+
+var (
+	wordListsMtx sync.RWMutex
+	wordLists    = map[string]wordList{}
+)
+
+func init() {
+	builtin := map[string][]string{
+		English:            EnglishWordList,
+		ChineseSimplified:  ChineseSimplifiedWordList,
+		ChineseTraditional: ChineseTraditionalWordList,
+		Japanese:           JapaneseWordList,
+		Korean:             KoreanWordList,
+		Spanish:            SpanishWordList,
+		French:             FrenchWordList,
+		Italian:            ItalianWordList,
+	}
+	for language, words := range builtin {
+		if err := RegisterWordList(language, words); err != nil {
+			panic(err)
+		}
+	}
+}
+
+// RegisterWordList registers the wordlist of one of the languages of the spec, so that mnemonics
+// can be created in it and mnemonics in it are recognized. The words are normalized in NFKD.
+func RegisterWordList(language string, words []string) error {
+	if !isLanguage(language) {
+		return fmt.Errorf("unknown language %q", language)
+	}
+	if len(words) != 2048 {
+		return fmt.Errorf("the wordlist must have 2048 words but got %d", len(words))
+	}
+	list := wordList{words: make([]string, len(words)), reverse: make(map[string]int, len(words))}
+	for i, word := range words {
+		word = norm.NFKD.String(strings.TrimSpace(word))
+		if word == "" || strings.ContainsAny(word, " \t\n") {
+			return fmt.Errorf("invalid word %q at %d", words[i], i)
+		}
+		if _, ok := list.reverse[word]; ok {
+			return fmt.Errorf("duplicate word %q", word)
+		}
+		list.words[i], list.reverse[word] = word, i
+	}
+
+	wordListsMtx.Lock()
+	defer wordListsMtx.Unlock()
+	wordLists[language] = list
+	return nil
+}
+
+// GetWordList returns the wordlist of the language, normalized in NFKD
+func GetWordList(language string) ([]string, error) {
+	list, err := getWordList(language)
+	if err != nil {
+		return nil, err
+	}
+	return append([]string{}, list.words...), nil
+}
+
+func getWordList(language string) (wordList, error) {
+	wordListsMtx.RLock()
+	defer wordListsMtx.RUnlock()
+	list, ok := wordLists[language]
+	if !ok {
+		return wordList{}, fmt.Errorf("no wordlist of language %q", language)
+	}
+	return list, nil
+}
+
+func isLanguage(language string) bool {
+	for _, l := range languages {
+		if l == language {
+			return true
+		}
+	}
+	return false
+}
+
+// NewMnemonicInLanguage returns the mnemonic of the entropy in the words of the language. The words
+// of a Japanese mnemonic are separated by ideographic spaces.
+func NewMnemonicInLanguage(entropy []byte, language string) (string, error) {
+	list, err := getWordList(language)
+	if err != nil {
+		return "", err
+	}
+	separator := " "
+	if language == Japanese {
+		separator = ideographicSpace
+	}
+	return newMnemonic(entropy, list.words, separator)
+}
+
+// NormalizeMnemonic normalizes the mnemonic in NFKD and separates its words by single spaces, so
+// that the mnemonics typed with composed characters or other spaces match the wordlists
+func NormalizeMnemonic(mnemonic string) string {
+	return strings.Join(strings.Fields(norm.NFKD.String(mnemonic)), " ")
+}
+
+// DetectLanguage returns the language of the mnemonic, whose words must all be in its wordlist.
+// The checksum tells apart the languages sharing the words of the mnemonic, like the simplified and
+// the traditional Chinese.
+func DetectLanguage(mnemonic string) (string, error) {
+	words := strings.Fields(NormalizeMnemonic(mnemonic))
+	if len(words) == 0 {
+		return "", fmt.Errorf("empty mnemonic")
+	}
+
+	var candidates []string
+	wordListsMtx.RLock()
+	for _, language := range languages {
+		list, ok := wordLists[language]
+		if ok && containsAll(list.reverse, words) {
+			candidates = append(candidates, language)
+		}
+	}
+	wordListsMtx.RUnlock()
+
+	switch len(candidates) {
+	case 0:
+		return "", fmt.Errorf("the mnemonic isn't in any known language")
+	case 1:
+		return candidates[0], nil
+	}
+	for _, language := range candidates {
+		list, _ := getWordList(language)
+		if _, err := mnemonicToByteArray(words, list.reverse); err == nil {
+			return language, nil
+		}
+	}
+	return candidates[0], nil
+}
+
+func containsAll(reverse map[string]int, words []string) bool {
+	for _, word := range words {
+		if _, ok := reverse[word]; !ok {
+			return false
+		}
+	}
+	return true
+}
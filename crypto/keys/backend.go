@@ -0,0 +1,95 @@
+package keys
+
+import (
+	"fmt"
+	"path/filepath"
+
+	"github.com/pkg/errors"
+)
+
+// Backend is the storage of a keybase
+type Backend string
+
+const (
+	// BackendMemory keeps the keys in memory only
+	BackendMemory = Backend("memory")
+	// BackendLevelDB keeps the keys in the goleveldb database used by okchaincli
+	BackendLevelDB = Backend("leveldb")
+	// BackendFile keeps every key in its own files of a directory
+	BackendFile = Backend("file")
+)
+
+const (
+	// KeysDirName is the directory of the keys under the home of okchaincli
+	KeysDirName = "keys"
+	// keysDBName is the name of the goleveldb database of okchaincli, which is stored in keys/keys.db
+	keysDBName = "keys"
+	// fileDirName is the directory of the file backend under KeysDirName
+	fileDirName = "files"
+)
+
+// NewKeyBaseFromDir opens the keybase of an okchaincli home like ~/.okchaincli, whose keys are in
+// the goleveldb database <rootDir>/keys/keys.db. Since goleveldb locks the database, the keybase
+// can't be open while okchaincli is using it. It must be closed by CloseDB.
+func NewKeyBaseFromDir(rootDir string) (Keybase, error) {
+	return New(keysDBName, filepath.Join(rootDir, KeysDirName))
+}
+
+// NewFileKeybase creates a keybase keeping every key in the files <name>.info and
+// <address>.address of dir. The infos are encoded as in the other backends, so the private keys
+// stay encrypted by their passphrases.
+func NewFileKeybase(dir string) (Keybase, error) {
+	db, err := newFileDB(dir)
+	if err != nil {
+		return nil, err
+	}
+	return newDbKeybase(db), nil
+}
+
+// NewKeybase opens the keybase of the backend under the home rootDir, which is ignored by the
+// memory backend. The leveldb backend shares the layout of okchaincli and the file backend is
+// stored in <rootDir>/keys/files.
+func NewKeybase(backend Backend, rootDir string) (Keybase, error) {
+	switch backend {
+	case BackendMemory:
+		return NewInMemory(), nil
+	case BackendLevelDB:
+		return NewKeyBaseFromDir(rootDir)
+	case BackendFile:
+		return NewFileKeybase(filepath.Join(rootDir, KeysDirName, fileDirName))
+	default:
+		return nil, fmt.Errorf("unsupported keybase backend %q, it's memory, leveldb or file", backend)
+	}
+}
+
+// Migrate copies all the keys of src to dst and returns the names of the copied keys. The infos
+// are copied as they're stored, so the private keys stay encrypted and no passphrase is needed.
+// A key of dst with the same name and address is skipped, so a broken migration can be run
+// again, while a different key under the same name fails it.
+func Migrate(src, dst Keybase) (migrated []string, err error) {
+	infos, err := src.List()
+	if err != nil {
+		return nil, err
+	}
+
+	for _, info := range infos {
+		name := info.GetName()
+		if existing, err := dst.Get(name); err == nil {
+			if !existing.GetAddress().Equals(info.GetAddress()) {
+				return migrated, fmt.Errorf("key %s of address %s already exists in the destination with address %s",
+					name, info.GetAddress(), existing.GetAddress())
+			}
+			continue
+		}
+
+		armor, err := src.Export(name)
+		if err != nil {
+			return migrated, errors.Wrapf(err, "failed to export key %s", name)
+		}
+		if err := dst.Import(name, armor); err != nil {
+			return migrated, errors.Wrapf(err, "failed to import key %s", name)
+		}
+		migrated = append(migrated, name)
+	}
+	return migrated, nil
+}
@@ -0,0 +1,107 @@
+package keys_test
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/okex/okchain-go-sdk/crypto/keys"
+	"github.com/okex/okchain-go-sdk/types"
+)
+
+const (
+	passWd   = "12345678"
+	mnemonic = "total lottery arena when pudding best candy until army spoil drill pool"
+	addr     = "okchain1g7c3nvac7mjgn2m9mqllgat8wwd3aptdqket5k"
+)
+
+func TestMigrate(t *testing.T) {
+	home, err := ioutil.TempDir("", "keybase")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(home)
+
+	mem := keys.NewInMemory()
+	if _, err := mem.CreateAccount("alice", mnemonic, "", passWd, 0, 0); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := mem.CreateAccount("bob", mnemonic, "", passWd, 0, 1); err != nil {
+		t.Fatal(err)
+	}
+	// the file names of the keys starting with a dot are escaped, so they aren't hidden
+	if _, err := mem.CreateAccount(".carol", mnemonic, "", passWd, 0, 2); err != nil {
+		t.Fatal(err)
+	}
+
+	// memory -> file
+	fileKb, err := keys.NewKeybase(keys.BackendFile, home)
+	if err != nil {
+		t.Fatal(err)
+	}
+	migrated, err := keys.Migrate(mem, fileKb)
+	if err != nil || len(migrated) != 3 {
+		t.Fatalf("unexpected migration: %v %v", migrated, err)
+	}
+	if _, err := os.Stat(filepath.Join(home, keys.KeysDirName, "files", "alice.info")); err != nil {
+		t.Fatalf("the info file isn't written: %s", err)
+	}
+	if listed, err := fileKb.List(); err != nil || len(listed) != 3 {
+		t.Fatalf("unexpected keys listed: %v %v", listed, err)
+	}
+	// a migration run again skips the copied keys
+	if migrated, err := keys.Migrate(mem, fileKb); err != nil || len(migrated) != 0 {
+		t.Fatalf("unexpected migration run again: %v %v", migrated, err)
+	}
+	// the records are replaced without leftovers of the longer ones
+	if err := fileKb.Update("alice", passWd, func() (string, error) { return "new", nil }); err != nil {
+		t.Fatal(err)
+	}
+	if _, _, err := fileKb.Sign("alice", "new", []byte("msg")); err != nil {
+		t.Fatalf("failed to sign after the update: %s", err)
+	}
+
+	// file -> leveldb of the okchaincli layout
+	levelKb, err := keys.NewKeyBaseFromDir(home)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := keys.Migrate(fileKb, levelKb); err != nil {
+		t.Fatal(err)
+	}
+	levelKb.CloseDB()
+	if _, err := os.Stat(filepath.Join(home, keys.KeysDirName, "keys.db")); err != nil {
+		t.Fatalf("the leveldb isn't in the okchaincli layout: %s", err)
+	}
+
+	levelKb, err = keys.NewKeybase(keys.BackendLevelDB, home)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer levelKb.CloseDB()
+	accAddr, _ := types.AccAddressFromBech32(addr)
+	if _, err := levelKb.Get(".carol"); err != nil {
+		t.Fatalf("the key starting with a dot isn't migrated: %s", err)
+	}
+	info, err := levelKb.GetByAddress(accAddr)
+	if err != nil || info.GetName() != "alice" {
+		t.Fatalf("the migrated key isn't found by its address: %v %v", info, err)
+	}
+	if _, _, err := levelKb.Sign("alice", "new", []byte("msg")); err != nil {
+		t.Fatalf("failed to sign with the migrated key: %s", err)
+	}
+
+	// a different key under an existing name fails the migration
+	other := keys.NewInMemory()
+	if _, err := other.CreateAccount("bob", mnemonic, "", passWd, 1, 0); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := keys.Migrate(other, levelKb); err == nil {
+		t.Fatal("a key is overwritten by the migration")
+	}
+
+	if _, err := keys.NewKeybase("unknown", home); err == nil {
+		t.Fatal("an unknown backend is accepted")
+	}
+}
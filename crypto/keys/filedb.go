@@ -0,0 +1,214 @@
+package keys
+
+import (
+	"fmt"
+	"io/ioutil"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+
+	"github.com/pkg/errors"
+	dbm "github.com/tendermint/tm-db"
+)
+
+const (
+	filePerm = os.FileMode(0600)
+	dirPerm  = os.FileMode(0700)
+)
+
+var _ dbm.DB = (*fileDB)(nil)
+
+// fileDB is a dbm.DB keeping every record in a file of the directory, named after the escaped key.
+// A key of the keybase is then made of the files <name>.info and <address>.address, which can be
+// backed up or inspected one by one. The records are replaced atomically by renaming a temporary
+// file, which tm-db's FSDB doesn't do. The file names of the records never start with a dot, which
+// is escaped too, so the temporary files can't be taken for records.
+type fileDB struct {
+	mtx sync.Mutex
+	dir string
+}
+
+func newFileDB(dir string) (*fileDB, error) {
+	if err := os.MkdirAll(dir, dirPerm); err != nil {
+		return nil, err
+	}
+	return &fileDB{dir: dir}, nil
+}
+
+func (db *fileDB) path(key []byte) string {
+	return filepath.Join(db.dir, fileName(key))
+}
+
+// fileName escapes the key into the name of its file
+func fileName(key []byte) string {
+	name := url.PathEscape(string(key))
+	if strings.HasPrefix(name, ".") {
+		name = "%2E" + name[1:]
+	}
+	return name
+}
+
+func (db *fileDB) Get(key []byte) []byte {
+	db.mtx.Lock()
+	defer db.mtx.Unlock()
+
+	value, err := ioutil.ReadFile(db.path(key))
+	if os.IsNotExist(err) {
+		return nil
+	} else if err != nil {
+		panic(errors.Wrapf(err, "reading key %s", key))
+	}
+	return value
+}
+
+func (db *fileDB) Has(key []byte) bool {
+	return db.Get(key) != nil
+}
+
+func (db *fileDB) Set(key []byte, value []byte) {
+	db.SetSync(key, value)
+}
+
+func (db *fileDB) SetSync(key []byte, value []byte) {
+	db.mtx.Lock()
+	defer db.mtx.Unlock()
+
+	if err := db.write(db.path(key), value); err != nil {
+		panic(errors.Wrapf(err, "writing key %s", key))
+	}
+}
+
+func (db *fileDB) write(path string, value []byte) error {
+	f, err := ioutil.TempFile(db.dir, ".tmp-")
+	if err != nil {
+		return err
+	}
+	tmpPath := f.Name()
+	if _, err = f.Write(value); err == nil {
+		err = f.Sync()
+	}
+	if closeErr := f.Close(); err == nil {
+		err = closeErr
+	}
+	if err == nil {
+		err = os.Chmod(tmpPath, filePerm)
+	}
+	if err == nil {
+		err = os.Rename(tmpPath, path)
+	}
+	if err != nil {
+		os.Remove(tmpPath)
+	}
+	return err
+}
+
+func (db *fileDB) Delete(key []byte) {
+	db.DeleteSync(key)
+}
+
+func (db *fileDB) DeleteSync(key []byte) {
+	db.mtx.Lock()
+	defer db.mtx.Unlock()
+
+	if err := os.Remove(db.path(key)); err != nil && !os.IsNotExist(err) {
+		panic(errors.Wrapf(err, "removing key %s", key))
+	}
+}
+
+// Iterator iterates over a snapshot of the records in the domain
+func (db *fileDB) Iterator(start, end []byte) dbm.Iterator {
+	return db.snapshot(start, end).Iterator(start, end)
+}
+
+func (db *fileDB) ReverseIterator(start, end []byte) dbm.Iterator {
+	return db.snapshot(start, end).ReverseIterator(start, end)
+}
+
+func (db *fileDB) snapshot(start, end []byte) dbm.DB {
+	db.mtx.Lock()
+	defer db.mtx.Unlock()
+
+	files, err := ioutil.ReadDir(db.dir)
+	if err != nil {
+		panic(errors.Wrapf(err, "listing keys in %s", db.dir))
+	}
+	snapshot := dbm.NewMemDB()
+	for _, file := range files {
+		// skip the directories and the temporary files left by a crash, whose names start with a
+		// dot unlike the escaped keys
+		if !file.Mode().IsRegular() || file.Name()[0] == '.' {
+			continue
+		}
+		key, err := url.PathUnescape(file.Name())
+		if err != nil || !dbm.IsKeyInDomain([]byte(key), start, end) {
+			continue
+		}
+		value, err := ioutil.ReadFile(filepath.Join(db.dir, file.Name()))
+		if err != nil {
+			panic(errors.Wrapf(err, "reading key %s", key))
+		}
+		snapshot.Set([]byte(key), value)
+	}
+	return snapshot
+}
+
+func (db *fileDB) Close() {}
+
+// NewBatch returns a batch which applies its writes in order on Write. Every record is replaced
+// atomically, but not the batch as a whole.
+func (db *fileDB) NewBatch() dbm.Batch {
+	return &fileBatch{db: db}
+}
+
+func (db *fileDB) Print() {
+	iter := db.Iterator(nil, nil)
+	defer iter.Close()
+	for ; iter.Valid(); iter.Next() {
+		fmt.Printf("[%X]:\t[%X]\n", iter.Key(), iter.Value())
+	}
+}
+
+func (db *fileDB) Stats() map[string]string {
+	return map[string]string{"database.type": "fileDB", "database.dir": db.dir}
+}
+
+type fileOp struct {
+	key    []byte
+	value  []byte
+	delete bool
+}
+
+// fileBatch is the batch of a fileDB
+type fileBatch struct {
+	db  *fileDB
+	ops []fileOp
+}
+
+func (b *fileBatch) Set(key, value []byte) {
+	b.ops = append(b.ops, fileOp{key: key, value: value})
+}
+
+func (b *fileBatch) Delete(key []byte) {
+	b.ops = append(b.ops, fileOp{key: key, delete: true})
+}
+
+func (b *fileBatch) Write() {
+	b.WriteSync()
+}
+
+func (b *fileBatch) WriteSync() {
+	for _, op := range b.ops {
+		if op.delete {
+			b.db.DeleteSync(op.key)
+		} else {
+			b.db.SetSync(op.key, op.value)
+		}
+	}
+	b.ops = nil
+}
+
+func (b *fileBatch) Close() {
+	b.ops = nil
+}
@@ -0,0 +1,40 @@
+package keys
+
+import (
+	"io/ioutil"
+	"os"
+	"testing"
+)
+
+func TestFileDBBatch(t *testing.T) {
+	dir, err := ioutil.TempDir("", "filedb")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	db, err := newFileDB(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	db.Set([]byte("a"), []byte("1"))
+
+	batch := db.NewBatch()
+	batch.Set([]byte("b"), []byte("2"))
+	batch.Set([]byte(".c"), []byte("3"))
+	batch.Delete([]byte("a"))
+	if !db.Has([]byte("a")) || db.Has([]byte("b")) {
+		t.Fatal("the batch is applied before Write")
+	}
+	batch.Write()
+	batch.Close()
+
+	iter := db.Iterator(nil, nil)
+	defer iter.Close()
+	var keys []string
+	for ; iter.Valid(); iter.Next() {
+		keys = append(keys, string(iter.Key()))
+	}
+	if len(keys) != 2 || keys[0] != ".c" || keys[1] != "b" {
+		t.Fatalf("unexpected keys after the batch: %v", keys)
+	}
+}
@@ -0,0 +1,275 @@
+package hd
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"math/big"
+	"strconv"
+	"strings"
+
+	"golang.org/x/crypto/ripemd160"
+
+	"github.com/okex/okchain-go-sdk/crypto/btcsuite/btcd/btcec"
+	"github.com/okex/okchain-go-sdk/crypto/btcsuite/btcutil/base58"
+)
+
+const (
+	// HardenedKeyStart is the index of the first hardened child key
+	HardenedKeyStart = uint32(0x80000000)
+
+	// the length of a serialized extended key before the checksum
+	serializedKeyLen = 4 + 1 + 4 + 4 + 32 + 33
+)
+
+var (
+	// XPrvVersion and XPubVersion are the BIP32 version bytes of the serialized private and public
+	// extended keys, which are encoded as xprv... and xpub...
+	XPrvVersion = [4]byte{0x04, 0x88, 0xad, 0xe4}
+	XPubVersion = [4]byte{0x04, 0x88, 0xb2, 0x1e}
+
+	// ErrDeriveHardenedFromPublic is returned when a hardened child is derived from a public key
+	ErrDeriveHardenedFromPublic = errors.New("cannot derive a hardened key from a public key")
+	// ErrInvalidChild is returned for the indexes whose child key is invalid, which happens with a
+	// probability lower than 1 in 2^127. The next index should be used instead.
+	ErrInvalidChild = errors.New("the child key of the index is invalid")
+)
+
+// ExtendedKey is a BIP32 extended key, the private or the public key of a node of the HD tree
+// along with its chain code. The public extended key of an account, like 44'/996'/0', derives
+// the public keys of all its addresses without the private key.
+type ExtendedKey struct {
+	key         []byte // the 32 bytes private key or the 33 bytes compressed public key
+	chainCode   [32]byte
+	depth       uint8
+	parentFP    [4]byte
+	childNumber uint32
+	isPrivate   bool
+}
+
+// NewMasterKey creates the master extended key of the seed, which is the root m of the HD paths
+func NewMasterKey(seed []byte) *ExtendedKey {
+	secret, chainCode := ComputeMastersFromSeed(seed)
+	return &ExtendedKey{
+		key:       secret[:],
+		chainCode: chainCode,
+		isPrivate: true,
+	}
+}
+
+// IsPrivate returns whether the extended key holds a private key
+func (k *ExtendedKey) IsPrivate() bool {
+	return k.isPrivate
+}
+
+// Depth returns the depth of the key in the HD tree, 0 for the master key
+func (k *ExtendedKey) Depth() uint8 {
+	return k.depth
+}
+
+// ChildNumber returns the index of the key in its parent, including the hardened offset
+func (k *ExtendedKey) ChildNumber() uint32 {
+	return k.childNumber
+}
+
+// PrivKey returns the private key. It fails for a public extended key.
+func (k *ExtendedKey) PrivKey() ([32]byte, error) {
+	var priv [32]byte
+	if !k.isPrivate {
+		return priv, errors.New("the extended key is public")
+	}
+	copy(priv[:], k.key)
+	return priv, nil
+}
+
+// PubKeyBytes returns the 33 bytes compressed public key
+func (k *ExtendedKey) PubKeyBytes() []byte {
+	if !k.isPrivate {
+		return k.key
+	}
+	_, pub := btcec.PrivKeyFromBytes(btcec.S256(), k.key)
+	return pub.SerializeCompressed()
+}
+
+// Neuter returns the public extended key of the key, which can only derive the non-hardened
+// public children
+func (k *ExtendedKey) Neuter() *ExtendedKey {
+	if !k.isPrivate {
+		return k
+	}
+	return &ExtendedKey{
+		key:         k.PubKeyBytes(),
+		chainCode:   k.chainCode,
+		depth:       k.depth,
+		parentFP:    k.parentFP,
+		childNumber: k.childNumber,
+	}
+}
+
+// Child derives the child key of the index, which is hardened if it's not lower than
+// HardenedKeyStart. A public key derives the public keys of its non-hardened children only.
+func (k *ExtendedKey) Child(index uint32) (*ExtendedKey, error) {
+	if k.depth == 255 {
+		return nil, errors.New("the extended key is at the max depth")
+	}
+	harden := index >= HardenedKeyStart
+	if harden && !k.isPrivate {
+		return nil, ErrDeriveHardenedFromPublic
+	}
+
+	child := &ExtendedKey{
+		depth:       k.depth + 1,
+		parentFP:    fingerprint(k.PubKeyBytes()),
+		childNumber: index,
+		isPrivate:   k.isPrivate,
+	}
+
+	if k.isPrivate {
+		var priv [32]byte
+		copy(priv[:], k.key)
+		childPriv, chainCode := derivePrivateKey(priv, k.chainCode, index&^HardenedKeyStart, harden)
+		if new(big.Int).SetBytes(childPriv[:]).Sign() == 0 {
+			return nil, ErrInvalidChild
+		}
+		child.key, child.chainCode = childPriv[:], chainCode
+		return child, nil
+	}
+
+	// the public child is point(IL) + parent public key
+	data := append(append([]byte{}, k.key...), uint32ToBytes(index)...)
+	il, chainCode := i64(k.chainCode[:], data)
+	if new(big.Int).SetBytes(il[:]).Cmp(btcec.S256().N) >= 0 {
+		return nil, ErrInvalidChild
+	}
+	parent, err := btcec.ParsePubKey(k.key, btcec.S256())
+	if err != nil {
+		return nil, err
+	}
+	ilX, ilY := btcec.S256().ScalarBaseMult(il[:])
+	x, y := btcec.S256().Add(ilX, ilY, parent.X, parent.Y)
+	if x.Sign() == 0 && y.Sign() == 0 {
+		return nil, ErrInvalidChild
+	}
+	child.key = (&btcec.PublicKey{Curve: btcec.S256(), X: x, Y: y}).SerializeCompressed()
+	child.chainCode = chainCode
+	return child, nil
+}
+
+// DerivePath derives the key of a BIP32 path like 44'/996'/0'/0/1 relative to the key. The path of
+// a master key may start with m/.
+func (k *ExtendedKey) DerivePath(path string) (*ExtendedKey, error) {
+	if strings.HasPrefix(path, "m/") || path == "m" {
+		if k.depth != 0 {
+			return nil, fmt.Errorf("absolute path %s of a key not at the root", path)
+		}
+		path = strings.TrimPrefix(strings.TrimPrefix(path, "m"), "/")
+	}
+	if path == "" {
+		return k, nil
+	}
+
+	key := k
+	for _, part := range strings.Split(path, "/") {
+		index, err := parseIndex(part)
+		if err != nil {
+			return nil, err
+		}
+		if key, err = key.Child(index); err != nil {
+			return nil, err
+		}
+	}
+	return key, nil
+}
+
+func parseIndex(part string) (uint32, error) {
+	harden := strings.HasSuffix(part, "'")
+	idx, err := strconv.ParseUint(strings.TrimSuffix(part, "'"), 10, 31)
+	if err != nil {
+		return 0, fmt.Errorf("invalid BIP 32 path index %q", part)
+	}
+	index := uint32(idx)
+	if harden {
+		index += HardenedKeyStart
+	}
+	return index, nil
+}
+
+// String serializes the key into the base58 form xprv... or xpub...
+func (k *ExtendedKey) String() string {
+	buf := make([]byte, 0, serializedKeyLen+4)
+	if k.isPrivate {
+		buf = append(buf, XPrvVersion[:]...)
+	} else {
+		buf = append(buf, XPubVersion[:]...)
+	}
+	buf = append(buf, k.depth)
+	buf = append(buf, k.parentFP[:]...)
+	buf = append(buf, uint32ToBytes(k.childNumber)...)
+	buf = append(buf, k.chainCode[:]...)
+	if k.isPrivate {
+		buf = append(buf, 0)
+	}
+	buf = append(buf, k.key...)
+	buf = append(buf, checksum(buf)...)
+	return base58.Encode(buf)
+}
+
+// NewExtendedKeyFromString parses a key serialized as xprv... or xpub...
+func NewExtendedKeyFromString(s string) (*ExtendedKey, error) {
+	decoded := base58.Decode(s)
+	if len(decoded) != serializedKeyLen+4 {
+		return nil, errors.New("invalid length of the extended key")
+	}
+	payload, sum := decoded[:serializedKeyLen], decoded[serializedKeyLen:]
+	if !bytes.Equal(checksum(payload), sum) {
+		return nil, errors.New("invalid checksum of the extended key")
+	}
+
+	k := &ExtendedKey{
+		depth:       payload[4],
+		childNumber: binary.BigEndian.Uint32(payload[9:13]),
+	}
+	copy(k.parentFP[:], payload[5:9])
+	copy(k.chainCode[:], payload[13:45])
+	keyData := payload[45:]
+
+	switch {
+	case bytes.Equal(payload[:4], XPrvVersion[:]):
+		if keyData[0] != 0 {
+			return nil, errors.New("invalid private key of the extended key")
+		}
+		priv := new(big.Int).SetBytes(keyData[1:])
+		if priv.Sign() == 0 || priv.Cmp(btcec.S256().N) >= 0 {
+			return nil, errors.New("invalid private key of the extended key")
+		}
+		k.key, k.isPrivate = keyData[1:], true
+	case bytes.Equal(payload[:4], XPubVersion[:]):
+		if _, err := btcec.ParsePubKey(keyData, btcec.S256()); err != nil {
+			return nil, fmt.Errorf("invalid public key of the extended key: %s", err)
+		}
+		k.key = keyData
+	default:
+		return nil, fmt.Errorf("unknown version %x of the extended key", payload[:4])
+	}
+	if k.depth == 0 && (k.childNumber != 0 || k.parentFP != [4]byte{}) {
+		return nil, errors.New("invalid master extended key")
+	}
+	return k, nil
+}
+
+// fingerprint is the first 4 bytes of the hash160 of the public key
+func fingerprint(pubKey []byte) (fp [4]byte) {
+	sha := sha256.Sum256(pubKey)
+	hasher := ripemd160.New()
+	hasher.Write(sha[:]) // nolint: errcheck
+	copy(fp[:], hasher.Sum(nil))
+	return
+}
+
+func checksum(payload []byte) []byte {
+	first := sha256.Sum256(payload)
+	second := sha256.Sum256(first[:])
+	return second[:4]
+}
@@ -0,0 +1,70 @@
+package hd
+
+import (
+	"bytes"
+	"encoding/hex"
+	"testing"
+)
+
+func TestExtendedKey(t *testing.T) {
+	// test vector 1 of BIP32
+	seed, _ := hex.DecodeString("000102030405060708090a0b0c0d0e0f")
+	master := NewMasterKey(seed)
+	if xpub := master.Neuter().String(); xpub != "xpub661MyMwAqRbcFtXgS5sYJABqqG9YLmC4Q1Rdap9gSE8NqtwybGhePY2gZ29ESFjqJoCu1Rupje8YtGqsefD265TMg7usUDFdp6W1EGMcet8" {
+		t.Fatalf("unexpected xpub of m: %s", xpub)
+	}
+	account, err := master.DerivePath("m/0'")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if xpub := account.Neuter().String(); xpub != "xpub68Gmy5EdvgibQVfPdqkBBCHxA5htiqg55crXYuXoQRKfDBFA1WEjWgP6LHhwBZeNK1VTsfTFUHCdrfp1bgwQ9xv5ski8PX9rL2dZXvgGDnw" {
+		t.Fatalf("unexpected xpub of m/0H: %s", xpub)
+	}
+
+	// the serialized keys are parsed back
+	for _, key := range []*ExtendedKey{account, account.Neuter()} {
+		parsed, err := NewExtendedKeyFromString(key.String())
+		if err != nil {
+			t.Fatal(err)
+		}
+		if parsed.String() != key.String() || parsed.IsPrivate() != key.IsPrivate() {
+			t.Fatalf("unexpected key parsed from %s", key)
+		}
+	}
+	invalid := []byte(account.String())
+	invalid[len(invalid)-1]++
+	if _, err := NewExtendedKeyFromString(string(invalid)); err == nil {
+		t.Fatal("a key with an invalid checksum is parsed")
+	}
+
+	// the public children match the public keys of the private children
+	private, err := account.DerivePath("1/2")
+	if err != nil {
+		t.Fatal(err)
+	}
+	public, err := account.Neuter().DerivePath("1/2")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if public.IsPrivate() || !bytes.Equal(public.PubKeyBytes(), private.PubKeyBytes()) || public.String() != private.Neuter().String() {
+		t.Fatal("the public derivation differs from the private one")
+	}
+	if _, err := account.Neuter().Child(HardenedKeyStart); err != ErrDeriveHardenedFromPublic {
+		t.Fatalf("expected an error deriving a hardened child from a public key but got %v", err)
+	}
+	if _, err := account.DerivePath("m/0"); err == nil {
+		t.Fatal("an absolute path is derived from a child key")
+	}
+
+	// the same keys as DerivePrivateKeyForPath
+	key, err := master.DerivePath(FullFundraiserPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	priv, _ := key.PrivKey()
+	secret, chainCode := ComputeMastersFromSeed(seed)
+	expected, _ := DerivePrivateKeyForPath(secret, chainCode, FullFundraiserPath)
+	if priv != expected {
+		t.Fatal("the extended key differs from the key derived for the path")
+	}
+}
@@ -181,6 +181,9 @@ func DerivePrivateKeyForPath(privKeyBytes [32]byte, chainCode [32]byte, path str
 	data := privKeyBytes
 	parts := strings.Split(path, "/")
 	for _, part := range parts {
+		if len(part) == 0 {
+			return [32]byte{}, errors.New("invalid BIP 32 path: empty index")
+		}
 		// do we have an apostrophe?
 		harden := part[len(part)-1:] == "'"
 		// harden == private derivation, else public derivation:
@@ -10,8 +10,10 @@ import (
 	"github.com/pkg/errors"
 
 	"github.com/okex/okchain-go-sdk/crypto"
+	"github.com/okex/okchain-go-sdk/crypto/ecies"
 	"github.com/okex/okchain-go-sdk/crypto/keys/hd"
 	"github.com/okex/okchain-go-sdk/crypto/keys/keyerror"
+	"github.com/okex/okchain-go-sdk/crypto/keys/keystore"
 	"github.com/okex/okchain-go-sdk/crypto/keys/mintkey"
 	"github.com/okex/okchain-go-sdk/types"
 
@@ -26,28 +28,21 @@ import (
 var _ Keybase = dbKeybase{}
 
 // Language is a language to create the BIP 39 mnemonic in.
-// Currently, only english is supported though.
 // Find a list of all supported languages in the BIP 39 spec (word lists).
+// The wordlists of all the languages are built into bip39.
 type Language int
 
 //noinspection ALL
 const (
 	// English is the default language to create a mnemonic.
-	// It is the only supported language by this package.
 	English Language = iota + 1
-	// Japanese is currently not supported.
+	// Japanese mnemonics are separated by ideographic spaces.
 	Japanese
-	// Korean is currently not supported.
 	Korean
-	// Spanish is currently not supported.
 	Spanish
-	// ChineseSimplified is currently not supported.
 	ChineseSimplified
-	// ChineseTraditional is currently not supported.
 	ChineseTraditional
-	// French is currently not supported.
 	French
-	// Italian is currently not supported.
 	Italian
 	addressSuffix = "address"
 	infoSuffix    = "info"
@@ -67,10 +62,22 @@ var (
 	ErrUnsupportedSigningAlgo = errors.New("unsupported signing algo: only secp256k1 is supported")
 
 	// ErrUnsupportedLanguage is raised when the caller tries to use a
-	// different language than english for creating a mnemonic sentence.
-	ErrUnsupportedLanguage = errors.New("unsupported language: only english is supported")
+	// language without a wordlist for creating a mnemonic sentence.
+	ErrUnsupportedLanguage = errors.New("unsupported language: no wordlist of the language is registered")
 )
 
+// bip39Languages maps the languages to the names of their wordlists in bip39
+var bip39Languages = map[Language]string{
+	English:            bip39.English,
+	Japanese:           bip39.Japanese,
+	Korean:             bip39.Korean,
+	Spanish:            bip39.Spanish,
+	ChineseSimplified:  bip39.ChineseSimplified,
+	ChineseTraditional: bip39.ChineseTraditional,
+	French:             bip39.French,
+	Italian:            bip39.Italian,
+}
+
 // dbKeybase combines encryption and storage implementation to provide
 // a full-featured key manager
 type dbKeybase struct {
@@ -88,6 +95,16 @@ func newDbKeybase(db dbm.DB) Keybase {
 // instance useful for testing purposes and on-the-fly key generation.
 func NewInMemory() Keybase { return dbKeybase{dbm.NewMemDB()} }
 
+// New creates a keybase persisted in the goleveldb database with the given name under dir.
+// It must be closed by CloseDB to release the lock of the database.
+func New(name, dir string) (Keybase, error) {
+	db, err := dbm.NewGoLevelDB(name, dir)
+	if err != nil {
+		return nil, err
+	}
+	return newDbKeybase(db), nil
+}
+
 // CreateMnemonic generates a new key and persists it to storage, encrypted
 // using the provided password.
 // It returns the generated mnemonic and the key Info.
@@ -95,7 +112,11 @@ func NewInMemory() Keybase { return dbKeybase{dbm.NewMemDB()} }
 // generate a key for the given algo type, or if another key is
 // already stored under the same name.
 func (kb dbKeybase) CreateMnemonic(name string, language Language, passwd string, algo SigningAlgo) (info Info, mnemonic string, err error) {
-	if language != English {
+	wordListLanguage, ok := bip39Languages[language]
+	if !ok {
+		return nil, "", ErrUnsupportedLanguage
+	}
+	if _, err = bip39.GetWordList(wordListLanguage); err != nil {
 		return nil, "", ErrUnsupportedLanguage
 	}
 	if algo != Secp256k1 {
@@ -109,7 +130,7 @@ func (kb dbKeybase) CreateMnemonic(name string, language Language, passwd string
 	if err != nil {
 		return
 	}
-	mnemonic, err = bip39.NewMnemonic(entropy)
+	mnemonic, err = bip39.NewMnemonicInLanguage(entropy, wordListLanguage)
 	if err != nil {
 		return
 	}
@@ -286,6 +307,16 @@ func (kb dbKeybase) Sign(name, passphrase string, msg []byte) (sig []byte, pub t
 	return sig, pub, nil
 }
 
+// Decrypt decrypts the data encrypted to the public key of the named key. Ledger and offline keys
+// can't decrypt.
+func (kb dbKeybase) Decrypt(name, passphrase string, ciphertext []byte) ([]byte, error) {
+	priv, err := kb.ExportPrivateKeyObject(name, passphrase)
+	if err != nil {
+		return nil, err
+	}
+	return ecies.Decrypt(priv, ciphertext)
+}
+
 func (kb dbKeybase) ExportPrivateKeyObject(name string, passphrase string) (tmcrypto.PrivKey, error) {
 	info, err := kb.Get(name)
 	if err != nil {
@@ -321,6 +352,31 @@ func (kb dbKeybase) Export(name string) (armor string, err error) {
 	return mintkey.ArmorInfoBytes(bz), nil
 }
 
+// ExportKeyStore exports a locally-stored key into the JSON keystore format, the hd path is
+// recorded as is
+func (kb dbKeybase) ExportKeyStore(name, decryptPassphrase, encryptPassphrase, hdPath string, params keystore.ScryptParams) ([]byte, error) {
+	priv, err := kb.ExportPrivateKeyObject(name, decryptPassphrase)
+	if err != nil {
+		return nil, err
+	}
+	return keystore.EncryptJSON(priv, encryptPassphrase, hdPath, params)
+}
+
+// ImportKeyStore decrypts a key of the JSON keystore format and stores it under the name
+func (kb dbKeybase) ImportKeyStore(name string, keyJSON []byte, decryptPassphrase, encryptPassphrase string) (Info, error) {
+	if len(kb.db.Get(infoKey(name))) > 0 {
+		return nil, errors.New("Cannot overwrite data for name " + name)
+	}
+	if encryptPassphrase == "" {
+		return nil, errors.New("a passphrase is required to encrypt the imported key")
+	}
+	priv, _, err := keystore.DecryptJSON(keyJSON, decryptPassphrase)
+	if err != nil {
+		return nil, err
+	}
+	return kb.writeLocalKey(name, priv, encryptPassphrase), nil
+}
+
 // ExportPubKey returns public keys in ASCII armored format.
 // Retrieve a Info object by its name and return the public key in
 // a portable format.
@@ -345,7 +401,13 @@ func (kb dbKeybase) Import(name string, armor string) (err error) {
 	if err != nil {
 		return
 	}
+	info, err := readInfo(infoBytes)
+	if err != nil {
+		return
+	}
+	// also index the key by its address for GetByAddress
 	kb.db.Set(infoKey(name), infoBytes)
+	kb.db.SetSync(addrKey(info.GetAddress()), infoKey(name))
 	return nil
 }
 
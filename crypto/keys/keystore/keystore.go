@@ -0,0 +1,272 @@
+// Package keystore implements a versioned JSON format of encrypted private keys, so that the keys
+// can be moved between the sdk and the wallets not based on Tendermint. The key is encrypted by
+// AES-256-GCM with a key derived from the passphrase by scrypt:
+//
+//	{
+//	  "version": 1,
+//	  "address": "okchain1...",
+//	  "pubkey": "okchainpub1...",
+//	  "hd_path": "44'/996'/0'/0/0",
+//	  "algo": "secp256k1",
+//	  "crypto": {
+//	    "cipher": "aes-256-gcm",
+//	    "ciphertext": "...",
+//	    "nonce": "...",
+//	    "kdf": "scrypt",
+//	    "kdfparams": {"n": 262144, "r": 8, "p": 1, "dklen": 32, "salt": "..."}
+//	  }
+//	}
+//
+// The address is authenticated along with the ciphertext, and it's checked against the decrypted
+// key, so the metadata of a keystore can't be swapped.
+package keystore
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"golang.org/x/crypto/scrypt"
+
+	"github.com/okex/okchain-go-sdk/crypto/keys/hd"
+	"github.com/okex/okchain-go-sdk/crypto/keys/keyerror"
+	"github.com/okex/okchain-go-sdk/types"
+	"github.com/tendermint/tendermint/crypto"
+	"github.com/tendermint/tendermint/crypto/secp256k1"
+)
+
+const (
+	// Version is the version of the keystore format written by Encrypt
+	Version = 1
+
+	algoSecp256k1 = "secp256k1"
+	cipherAESGCM  = "aes-256-gcm"
+	kdfScrypt     = "scrypt"
+	keyLen        = 32
+	saltLen       = 32
+
+	// the bounds of the scrypt params, so that a crafted keystore can't exhaust the memory or the
+	// CPU before its password is checked. scrypt takes 128*N*r bytes of memory.
+	maxScryptN      = 1 << 20
+	maxScryptR      = 32
+	maxScryptP      = 16
+	maxScryptMemory = 1 << 30
+)
+
+// ScryptParams are the cost parameters of the scrypt KDF
+type ScryptParams struct {
+	N int `json:"n"`
+	R int `json:"r"`
+	P int `json:"p"`
+}
+
+var (
+	// DefaultScryptParams is the cost used by the wallets, it takes about a second to derive a key
+	DefaultScryptParams = ScryptParams{N: 1 << 18, R: 8, P: 1}
+	// LightScryptParams is a cost suitable for tests and devices with little memory
+	LightScryptParams = ScryptParams{N: 1 << 12, R: 8, P: 6}
+)
+
+// Validate checks that the parameters are accepted by scrypt, and that they don't cost more than
+// N = 2^20, r = 32, p = 16 and 1 GiB of memory
+func (p ScryptParams) Validate() error {
+	if p.N <= 1 || p.N&(p.N-1) != 0 || p.N > maxScryptN {
+		return fmt.Errorf("scrypt N must be a power of 2 between 2 and %d, got %d", maxScryptN, p.N)
+	}
+	if p.R <= 0 || p.R > maxScryptR || p.P <= 0 || p.P > maxScryptP {
+		return fmt.Errorf("invalid scrypt r %d and p %d, r must be at most %d and p at most %d", p.R, p.P, maxScryptR, maxScryptP)
+	}
+	if 128*uint64(p.N)*uint64(p.R) > maxScryptMemory {
+		return fmt.Errorf("scrypt N %d and r %d take more than %d bytes of memory", p.N, p.R, maxScryptMemory)
+	}
+	return nil
+}
+
+// KeyStore is the content of a keystore file
+type KeyStore struct {
+	Version int    `json:"version"`
+	Address string `json:"address"`
+	PubKey  string `json:"pubkey"`
+	// HDPath is the BIP44 path the key was derived with. It's informative only and may be empty.
+	HDPath string       `json:"hd_path,omitempty"`
+	Algo   string       `json:"algo"`
+	Crypto CryptoParams `json:"crypto"`
+}
+
+// CryptoParams describes the encryption of the private key
+type CryptoParams struct {
+	Cipher     string    `json:"cipher"`
+	CipherText string    `json:"ciphertext"`
+	Nonce      string    `json:"nonce"`
+	KDF        string    `json:"kdf"`
+	KDFParams  KDFParams `json:"kdfparams"`
+}
+
+// KDFParams are the parameters of the scrypt KDF stored in a keystore
+type KDFParams struct {
+	ScryptParams
+	DKLen int    `json:"dklen"`
+	Salt  string `json:"salt"`
+}
+
+// Encrypt encrypts the private key with the passphrase into a keystore. The HD path is optional.
+func Encrypt(privKey crypto.PrivKey, passphrase, hdPath string, params ScryptParams) (KeyStore, error) {
+	secpPriv, ok := privKey.(secp256k1.PrivKeySecp256k1)
+	if !ok {
+		return KeyStore{}, fmt.Errorf("only secp256k1 keys are supported, got %T", privKey)
+	}
+	if err := params.Validate(); err != nil {
+		return KeyStore{}, err
+	}
+	if hdPath != "" {
+		if _, err := hd.NewParamsFromPath(hdPath); err != nil {
+			return KeyStore{}, fmt.Errorf("invalid hd path %q: %s", hdPath, err)
+		}
+	}
+
+	pubKey := privKey.PubKey()
+	address := types.AccAddress(pubKey.Address())
+	bech32PubKey, err := types.Bech32ifyAccPub(pubKey)
+	if err != nil {
+		return KeyStore{}, err
+	}
+
+	salt := crypto.CRandBytes(saltLen)
+	aead, err := newAEAD(passphrase, salt, params)
+	if err != nil {
+		return KeyStore{}, err
+	}
+	nonce := crypto.CRandBytes(aead.NonceSize())
+	cipherText := aead.Seal(nil, nonce, secpPriv[:], address)
+
+	return KeyStore{
+		Version: Version,
+		Address: address.String(),
+		PubKey:  bech32PubKey,
+		HDPath:  hdPath,
+		Algo:    algoSecp256k1,
+		Crypto: CryptoParams{
+			Cipher:     cipherAESGCM,
+			CipherText: hex.EncodeToString(cipherText),
+			Nonce:      hex.EncodeToString(nonce),
+			KDF:        kdfScrypt,
+			KDFParams: KDFParams{
+				ScryptParams: params,
+				DKLen:        keyLen,
+				Salt:         hex.EncodeToString(salt),
+			},
+		},
+	}, nil
+}
+
+// EncryptJSON encrypts the private key into the JSON encoding of a keystore
+func EncryptJSON(privKey crypto.PrivKey, passphrase, hdPath string, params ScryptParams) ([]byte, error) {
+	ks, err := Encrypt(privKey, passphrase, hdPath, params)
+	if err != nil {
+		return nil, err
+	}
+	return json.MarshalIndent(ks, "", "  ")
+}
+
+// Decrypt decrypts the private key of the keystore. It returns keyerror.ErrWrongPassword if the
+// passphrase is wrong.
+func (ks KeyStore) Decrypt(passphrase string) (crypto.PrivKey, error) {
+	if ks.Version != Version {
+		return nil, fmt.Errorf("unsupported keystore version %d", ks.Version)
+	}
+	if ks.Algo != algoSecp256k1 {
+		return nil, fmt.Errorf("unsupported key algo %q", ks.Algo)
+	}
+	if ks.Crypto.Cipher != cipherAESGCM || ks.Crypto.KDF != kdfScrypt {
+		return nil, fmt.Errorf("unsupported cipher %q or kdf %q", ks.Crypto.Cipher, ks.Crypto.KDF)
+	}
+	if ks.Crypto.KDFParams.DKLen != keyLen {
+		return nil, fmt.Errorf("unsupported derived key length %d", ks.Crypto.KDFParams.DKLen)
+	}
+
+	address, err := types.AccAddressFromBech32(ks.Address)
+	if err != nil {
+		return nil, fmt.Errorf("invalid address of the keystore: %s", err)
+	}
+	salt, err := hex.DecodeString(ks.Crypto.KDFParams.Salt)
+	if err != nil {
+		return nil, fmt.Errorf("invalid salt: %s", err)
+	}
+	nonce, err := hex.DecodeString(ks.Crypto.Nonce)
+	if err != nil {
+		return nil, fmt.Errorf("invalid nonce: %s", err)
+	}
+	cipherText, err := hex.DecodeString(ks.Crypto.CipherText)
+	if err != nil {
+		return nil, fmt.Errorf("invalid ciphertext: %s", err)
+	}
+
+	aead, err := newAEAD(passphrase, salt, ks.Crypto.KDFParams.ScryptParams)
+	if err != nil {
+		return nil, err
+	}
+	if len(nonce) != aead.NonceSize() {
+		return nil, fmt.Errorf("invalid nonce length %d", len(nonce))
+	}
+	plainText, err := aead.Open(nil, nonce, cipherText, address)
+	if err != nil {
+		return nil, keyerror.NewErrWrongPassword()
+	}
+	if len(plainText) != keyLen {
+		return nil, fmt.Errorf("invalid private key length %d", len(plainText))
+	}
+
+	var privKey secp256k1.PrivKeySecp256k1
+	copy(privKey[:], plainText)
+	if err := ks.verify(privKey); err != nil {
+		return nil, err
+	}
+	return privKey, nil
+}
+
+// verify checks the metadata of the keystore against the decrypted key
+func (ks KeyStore) verify(privKey crypto.PrivKey) error {
+	pubKey := privKey.PubKey()
+	if address := types.AccAddress(pubKey.Address()).String(); address != ks.Address {
+		return fmt.Errorf("the key of the keystore belongs to %s instead of %s", address, ks.Address)
+	}
+	if ks.PubKey != "" {
+		bech32PubKey, err := types.Bech32ifyAccPub(pubKey)
+		if err != nil {
+			return err
+		}
+		if bech32PubKey != ks.PubKey {
+			return errors.New("the pubkey of the keystore doesn't match its private key")
+		}
+	}
+	return nil
+}
+
+// DecryptJSON decrypts the private key of a JSON encoded keystore. The keystore is also returned
+// for its metadata.
+func DecryptJSON(keyJSON []byte, passphrase string) (crypto.PrivKey, KeyStore, error) {
+	var ks KeyStore
+	if err := json.Unmarshal(keyJSON, &ks); err != nil {
+		return nil, ks, fmt.Errorf("invalid keystore: %s", err)
+	}
+	privKey, err := ks.Decrypt(passphrase)
+	return privKey, ks, err
+}
+
+func newAEAD(passphrase string, salt []byte, params ScryptParams) (cipher.AEAD, error) {
+	if err := params.Validate(); err != nil {
+		return nil, err
+	}
+	derivedKey, err := scrypt.Key([]byte(passphrase), salt, params.N, params.R, params.P, keyLen)
+	if err != nil {
+		return nil, err
+	}
+	block, err := aes.NewCipher(derivedKey)
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
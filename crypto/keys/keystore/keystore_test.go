@@ -0,0 +1,94 @@
+package keystore_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/okex/okchain-go-sdk/crypto/keys"
+	"github.com/okex/okchain-go-sdk/crypto/keys/keyerror"
+	"github.com/okex/okchain-go-sdk/crypto/keys/keystore"
+	"github.com/tendermint/tendermint/crypto/secp256k1"
+)
+
+const (
+	passWd   = "12345678"
+	mnemonic = "total lottery arena when pudding best candy until army spoil drill pool"
+	addr     = "okchain1g7c3nvac7mjgn2m9mqllgat8wwd3aptdqket5k"
+	hdPath   = "44'/996'/0'/0/0"
+)
+
+func TestEncryptDecrypt(t *testing.T) {
+	priv := secp256k1.GenPrivKey()
+	keyJSON, err := keystore.EncryptJSON(priv, passWd, hdPath, keystore.LightScryptParams)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	decrypted, ks, err := keystore.DecryptJSON(keyJSON, passWd)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !decrypted.Equals(priv) {
+		t.Fatal("the decrypted key differs from the encrypted one")
+	}
+	if ks.Version != keystore.Version || ks.HDPath != hdPath || ks.Crypto.KDFParams.ScryptParams != keystore.LightScryptParams {
+		t.Fatalf("unexpected metadata of the keystore: %+v", ks)
+	}
+
+	if _, _, err := keystore.DecryptJSON(keyJSON, "wrong password"); !keyerror.IsErrWrongPassword(err) {
+		t.Fatalf("expected a wrong password error but got %v", err)
+	}
+
+	// the address is authenticated with the ciphertext
+	ks.Address = addr
+	tampered, _ := json.Marshal(ks)
+	if _, _, err := keystore.DecryptJSON(tampered, passWd); err == nil {
+		t.Fatal("a keystore with a swapped address is decrypted")
+	}
+
+	if _, err := keystore.Encrypt(priv, passWd, "", keystore.ScryptParams{N: 1000, R: 8, P: 1}); err == nil {
+		t.Fatal("an invalid scrypt N is accepted")
+	}
+	// a keystore whose scrypt params would exhaust the memory is rejected before deriving the key
+	for _, params := range []keystore.ScryptParams{{N: 1 << 30, R: 8, P: 1}, {N: 1 << 12, R: 1 << 20, P: 1}, {N: 1 << 20, R: 16, P: 1}, {N: 1 << 12, R: 8, P: 1 << 20}} {
+		ks.Crypto.KDFParams.ScryptParams = params
+		crafted, _ := json.Marshal(ks)
+		if _, _, err := keystore.DecryptJSON(crafted, passWd); err == nil || keyerror.IsErrWrongPassword(err) {
+			t.Fatalf("expected invalid scrypt params %+v but got %v", params, err)
+		}
+	}
+
+	if _, err := keystore.Encrypt(priv, passWd, "m/invalid", keystore.LightScryptParams); err == nil {
+		t.Fatal("an invalid hd path is accepted")
+	}
+}
+
+func TestKeybaseExportImport(t *testing.T) {
+	kb := keys.NewInMemory()
+	if _, err := kb.CreateAccount("alice", mnemonic, "", passWd, 0, 0); err != nil {
+		t.Fatal(err)
+	}
+
+	keyJSON, err := kb.ExportKeyStore("alice", passWd, "export password", hdPath, keystore.LightScryptParams)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := kb.ExportKeyStore("alice", "wrong password", "export password", hdPath, keystore.LightScryptParams); err == nil {
+		t.Fatal("a key is exported with a wrong password")
+	}
+
+	other := keys.NewInMemory()
+	info, err := other.ImportKeyStore("bob", keyJSON, "export password", "new password")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if info.GetAddress().String() != addr {
+		t.Fatalf("unexpected address of the imported key: %s", info.GetAddress())
+	}
+	if _, _, err := other.Sign("bob", "new password", []byte("msg")); err != nil {
+		t.Fatalf("the imported key can't sign: %s", err)
+	}
+	if _, err := other.ImportKeyStore("bob", keyJSON, "export password", "new password"); err == nil {
+		t.Fatal("an existing key is overwritten")
+	}
+}
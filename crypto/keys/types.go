@@ -3,6 +3,7 @@ package keys
 import (
 	"fmt"
 	"github.com/okex/okchain-go-sdk/crypto/keys/hd"
+	"github.com/okex/okchain-go-sdk/crypto/keys/keystore"
 	"github.com/okex/okchain-go-sdk/types"
 	"github.com/tendermint/tendermint/crypto"
 	"github.com/tendermint/tendermint/crypto/multisig"
@@ -19,11 +20,15 @@ type Keybase interface {
 	// Sign some bytes, looking up the private key to use
 	Sign(name, passphrase string, msg []byte) ([]byte, crypto.PubKey, error)
 
+	// Decrypt decrypts the data encrypted by the ecies package to the public key of the named key.
+	// It *only* works on locally-stored keys.
+	Decrypt(name, passphrase string, ciphertext []byte) ([]byte, error)
+
 	// CreateMnemonic creates a new mnemonic, and derives a hierarchical deterministic
 	// key from that.
 	CreateMnemonic(name string, language Language, passwd string, algo SigningAlgo) (info Info, seed string, err error)
 
-	// CreateAccount creates an account based using the BIP44 path (44'/118'/{account}'/0/{index}
+	// CreateAccount creates an account based using the BIP44 path 44'/996'/{account}'/0/{index}
 	CreateAccount(name, mnemonic, bip39Passwd, encryptPasswd string, account uint32, index uint32) (Info, error)
 
 	// Derive computes a BIP39 seed from th mnemonic and bip39Passwd.
@@ -48,6 +53,12 @@ type Keybase interface {
 	Export(name string) (armor string, err error)
 	ExportPubKey(name string) (armor string, err error)
 
+	// ExportKeyStore exports a locally-stored key into the JSON keystore format, encrypted with
+	// encryptPassphrase by scrypt with the given params. The HD path is only recorded as metadata.
+	ExportKeyStore(name, decryptPassphrase, encryptPassphrase, hdPath string, params keystore.ScryptParams) ([]byte, error)
+	// ImportKeyStore imports a key from the JSON keystore format and stores it encrypted with encryptPassphrase
+	ImportKeyStore(name string, keyJSON []byte, decryptPassphrase, encryptPassphrase string) (Info, error)
+
 	// ExportPrivateKeyObject *only* works on locally-stored keys. Temporary method until we redo the exporting API
 	ExportPrivateKeyObject(name string, passphrase string) (crypto.PrivKey, error)
 
@@ -0,0 +1,192 @@
+package shamir
+
+import (
+	"bytes"
+	"crypto/rand"
+	"crypto/sha256"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/okex/okchain-go-sdk/crypto/go-bip39"
+)
+
+const (
+	// a share is encoded as the id of the split, the threshold, the language of the mnemonic, the x
+	// coordinate, the share of the entropy and a checksum
+	shareHeaderLen   = 2 + 1 + 1 + 1
+	shareChecksumLen = 4
+	bitsPerWord      = 11
+)
+
+// mnemonicLanguages are the languages of the mnemonics, whose index is the one in the shares
+var mnemonicLanguages = []string{
+	bip39.English,
+	bip39.ChineseSimplified,
+	bip39.ChineseTraditional,
+	bip39.Japanese,
+	bip39.Korean,
+	bip39.Spanish,
+	bip39.French,
+	bip39.Italian,
+}
+
+// SplitMnemonic splits the entropy of a bip39 mnemonic into n shares, any threshold of which
+// recover the mnemonic in its language by CombineMnemonic. Every share is encoded as words of the
+// english bip39 wordlist with a checksum, e.g. 19 words for a mnemonic of 12 words and 30 words for
+// one of 24 words.
+func SplitMnemonic(mnemonic string, n, threshold int) ([]string, error) {
+	mnemonic = strings.Join(strings.Fields(mnemonic), " ")
+	entropy, err := bip39.EntropyFromMnemonic(mnemonic)
+	if err != nil {
+		return nil, fmt.Errorf("invalid mnemonic: %s", err)
+	}
+	language, err := bip39.DetectLanguage(mnemonic)
+	if err != nil {
+		return nil, fmt.Errorf("invalid mnemonic: %s", err)
+	}
+	languageIndex := -1
+	for i, l := range mnemonicLanguages {
+		if l == language {
+			languageIndex = i
+		}
+	}
+	if languageIndex < 0 {
+		return nil, fmt.Errorf("unsupported language %s of the mnemonic", language)
+	}
+
+	shares, err := Split(entropy, n, threshold)
+	if err != nil {
+		return nil, err
+	}
+	// the id tells the shares of different splits apart
+	id := make([]byte, 2)
+	if _, err := rand.Read(id); err != nil {
+		return nil, err
+	}
+
+	mnemonicShares := make([]string, len(shares))
+	for i, share := range shares {
+		data := make([]byte, 0, shareHeaderLen+len(share.Y)+shareChecksumLen)
+		data = append(data, id...)
+		data = append(data, byte(threshold), byte(languageIndex), share.X)
+		data = append(data, share.Y...)
+		data = append(data, shareChecksum(data)...)
+		mnemonicShares[i] = encodeWords(data)
+	}
+	return mnemonicShares, nil
+}
+
+// CombineMnemonic recovers the mnemonic from its shares made by SplitMnemonic. It fails if there
+// are fewer shares than the threshold, or if a share is corrupted or belongs to another split.
+func CombineMnemonic(mnemonicShares []string) (string, error) {
+	if len(mnemonicShares) == 0 {
+		return "", errors.New("no share to combine")
+	}
+
+	var id []byte
+	var threshold, languageIndex byte
+	shares := make([]Share, len(mnemonicShares))
+	for i, s := range mnemonicShares {
+		length, err := shareLen(len(strings.Fields(s)))
+		if err != nil {
+			return "", fmt.Errorf("invalid share %d: %s", i+1, err)
+		}
+		data, err := decodeWords(s, length)
+		if err != nil {
+			return "", fmt.Errorf("invalid share %d: %s", i+1, err)
+		}
+		payload, sum := data[:len(data)-shareChecksumLen], data[len(data)-shareChecksumLen:]
+		if !bytes.Equal(shareChecksum(payload), sum) {
+			return "", fmt.Errorf("invalid checksum of share %d", i+1)
+		}
+
+		if i == 0 {
+			id, threshold, languageIndex = payload[:2], payload[2], payload[3]
+		} else if !bytes.Equal(payload[:2], id) || payload[2] != threshold || payload[3] != languageIndex {
+			return "", fmt.Errorf("share %d belongs to another split", i+1)
+		}
+		shares[i] = Share{X: payload[4], Y: payload[shareHeaderLen:]}
+	}
+	if len(shares) < int(threshold) {
+		return "", fmt.Errorf("%d shares are required but got %d", threshold, len(shares))
+	}
+	if int(languageIndex) >= len(mnemonicLanguages) {
+		return "", fmt.Errorf("unknown language %d of the mnemonic", languageIndex)
+	}
+
+	entropy, err := Combine(shares)
+	if err != nil {
+		return "", err
+	}
+	mnemonic, err := bip39.NewMnemonicInLanguage(entropy, mnemonicLanguages[languageIndex])
+	if err != nil {
+		return "", err
+	}
+	if !bip39.IsMnemonicValid(mnemonic) {
+		return "", errors.New("the recovered mnemonic is invalid")
+	}
+	return mnemonic, nil
+}
+
+func shareChecksum(payload []byte) []byte {
+	sum := sha256.Sum256(payload)
+	return sum[:shareChecksumLen]
+}
+
+// encodeWords encodes the data as words of 11 bits, padding the last word with zero bits
+func encodeWords(data []byte) string {
+	words := make([]string, (len(data)*8+bitsPerWord-1)/bitsPerWord)
+	for i := range words {
+		index := 0
+		for bit := i * bitsPerWord; bit < (i+1)*bitsPerWord; bit++ {
+			index <<= 1
+			if bit < len(data)*8 && data[bit/8]&(0x80>>uint(bit%8)) != 0 {
+				index |= 1
+			}
+		}
+		words[i] = bip39.WordList[index]
+	}
+	return strings.Join(words, " ")
+}
+
+// decodeWords decodes the words encoded by encodeWords into data of the length, checking that the
+// padding bits are zero
+func decodeWords(s string, length int) ([]byte, error) {
+	words := strings.Fields(s)
+	bits := len(words) * bitsPerWord
+	if bits < length*8 || bits-length*8 >= bitsPerWord {
+		return nil, fmt.Errorf("invalid number of words %d", len(words))
+	}
+	data := make([]byte, (bits+7)/8)
+	for i, word := range words {
+		index, ok := bip39.ReverseWordMap[word]
+		if !ok {
+			return nil, fmt.Errorf("unknown word %q", word)
+		}
+		for j := 0; j < bitsPerWord; j++ {
+			if index&(1<<uint(bitsPerWord-1-j)) != 0 {
+				bit := i*bitsPerWord + j
+				data[bit/8] |= 0x80 >> uint(bit%8)
+			}
+		}
+	}
+	for bit := length * 8; bit < bits; bit++ {
+		if data[bit/8]&(0x80>>uint(bit%8)) != 0 {
+			return nil, errors.New("invalid padding bits")
+		}
+	}
+	return data[:length], nil
+}
+
+// shareLen returns the length of the data of a share of the number of words, which is unique for
+// every entropy size of bip39
+func shareLen(words int) (int, error) {
+	for entropyLen := 16; entropyLen <= 32; entropyLen += 4 {
+		length := shareHeaderLen + entropyLen + shareChecksumLen
+		if (length*8+bitsPerWord-1)/bitsPerWord == words {
+			return length, nil
+		}
+	}
+	return 0, fmt.Errorf("invalid number of words %d", words)
+}
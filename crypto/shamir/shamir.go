@@ -0,0 +1,132 @@
+// Package shamir splits secrets into shares by Shamir's secret sharing over GF(256), so that any
+// threshold of the shares recover the secret while fewer shares reveal nothing about it. Every
+// byte of the secret is the constant term of its own random polynomial, whose degree is the
+// threshold minus one, and a share holds the values of the polynomials at its x coordinate.
+package shamir
+
+import (
+	"crypto/rand"
+	"errors"
+	"fmt"
+)
+
+// MaxShares is the max number of shares, since the x coordinates are the non-zero bytes
+const MaxShares = 255
+
+// exp and log are the tables of the powers of the generator 3 in GF(256) with the polynomial
+// x^8 + x^4 + x^3 + x + 1 of AES
+var exp, log [256]byte
+
+func init() {
+	x := byte(1)
+	for i := 0; i < 255; i++ {
+		exp[i] = x
+		log[x] = byte(i)
+		// multiply by the generator 3: x*2 + x
+		x ^= xtime(x)
+	}
+	exp[255] = exp[0]
+}
+
+func xtime(x byte) byte {
+	if x&0x80 != 0 {
+		return x<<1 ^ 0x1b
+	}
+	return x << 1
+}
+
+func mul(a, b byte) byte {
+	if a == 0 || b == 0 {
+		return 0
+	}
+	return exp[(int(log[a])+int(log[b]))%255]
+}
+
+func div(a, b byte) byte {
+	if b == 0 {
+		panic("division by zero in GF(256)")
+	}
+	if a == 0 {
+		return 0
+	}
+	return exp[(int(log[a])+255-int(log[b]))%255]
+}
+
+// Share is a share of a secret, the values of the polynomials of the secret bytes at X
+type Share struct {
+	X byte
+	Y []byte
+}
+
+// Split splits the secret into n shares, any threshold of which recover it
+func Split(secret []byte, n, threshold int) ([]Share, error) {
+	if len(secret) == 0 {
+		return nil, errors.New("empty secret")
+	}
+	if threshold < 2 || threshold > n || n > MaxShares {
+		return nil, fmt.Errorf("the threshold must be between 2 and the number of shares, which is %d at most; got %d of %d", MaxShares, threshold, n)
+	}
+
+	shares := make([]Share, n)
+	for i := range shares {
+		shares[i] = Share{X: byte(i + 1), Y: make([]byte, len(secret))}
+	}
+
+	coefficients := make([]byte, threshold)
+	for j, b := range secret {
+		coefficients[0] = b
+		if _, err := rand.Read(coefficients[1:]); err != nil {
+			return nil, err
+		}
+		for i := range shares {
+			shares[i].Y[j] = evaluate(coefficients, shares[i].X)
+		}
+	}
+	return shares, nil
+}
+
+// evaluate evaluates the polynomial of the coefficients at x by Horner's method
+func evaluate(coefficients []byte, x byte) byte {
+	var y byte
+	for i := len(coefficients) - 1; i >= 0; i-- {
+		y = mul(y, x) ^ coefficients[i]
+	}
+	return y
+}
+
+// Combine recovers the secret by the Lagrange interpolation of the shares at 0. Fewer shares than
+// the threshold of the split recover a wrong secret, which can't be detected here.
+func Combine(shares []Share) ([]byte, error) {
+	if len(shares) < 2 {
+		return nil, errors.New("at least 2 shares are required")
+	}
+	length := len(shares[0].Y)
+	seen := make(map[byte]bool, len(shares))
+	for _, share := range shares {
+		if share.X == 0 {
+			return nil, errors.New("invalid share of x 0")
+		}
+		if seen[share.X] {
+			return nil, fmt.Errorf("duplicate share of x %d", share.X)
+		}
+		seen[share.X] = true
+		if len(share.Y) != length {
+			return nil, errors.New("the shares are of different lengths")
+		}
+	}
+
+	secret := make([]byte, length)
+	for i, share := range shares {
+		// the Lagrange basis polynomial of the share at 0
+		basis := byte(1)
+		for j, other := range shares {
+			if i != j {
+				basis = mul(basis, div(other.X, other.X^share.X))
+			}
+		}
+		for k, y := range share.Y {
+			secret[k] ^= mul(y, basis)
+		}
+	}
+	return secret, nil
+}
@@ -0,0 +1,102 @@
+package shamir
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/okex/okchain-go-sdk/crypto/go-bip39"
+)
+
+func TestSplit(t *testing.T) {
+	secret := []byte("the secret of the treasury")
+	shares, err := Split(secret, 5, 3)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// any 3 shares recover the secret
+	for i := 0; i < 5; i++ {
+		for j := i + 1; j < 5; j++ {
+			for k := j + 1; k < 5; k++ {
+				recovered, err := Combine([]Share{shares[k], shares[i], shares[j]})
+				if err != nil {
+					t.Fatal(err)
+				}
+				if !bytes.Equal(recovered, secret) {
+					t.Fatalf("unexpected secret recovered from the shares %d, %d and %d: %x", i, j, k, recovered)
+				}
+			}
+		}
+	}
+	if recovered, err := Combine(shares[:2]); err == nil && bytes.Equal(recovered, secret) {
+		t.Fatal("the secret is recovered from fewer shares than the threshold")
+	}
+	if _, err := Combine([]Share{shares[0], shares[0], shares[1]}); err == nil {
+		t.Fatal("duplicate shares are combined")
+	}
+
+	for _, params := range [][2]int{{3, 1}, {3, 4}, {256, 2}} {
+		if _, err := Split(secret, params[0], params[1]); err == nil {
+			t.Fatalf("the secret is split into %d shares of threshold %d", params[0], params[1])
+		}
+	}
+}
+
+func TestSplitMnemonic(t *testing.T) {
+	for _, bitSize := range []int{128, 160, 256} {
+		entropy, _ := bip39.NewEntropy(bitSize)
+		mnemonic, _ := bip39.NewMnemonic(entropy)
+		shares, err := SplitMnemonic(mnemonic, 3, 2)
+		if err != nil {
+			t.Fatal(err)
+		}
+		for _, pair := range [][2]int{{0, 1}, {2, 0}, {1, 2}} {
+			recovered, err := CombineMnemonic([]string{shares[pair[0]], shares[pair[1]]})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if recovered != mnemonic {
+				t.Fatalf("unexpected mnemonic recovered: %s", recovered)
+			}
+		}
+
+		if _, err := CombineMnemonic(shares[:1]); err == nil {
+			t.Fatal("the mnemonic is recovered from fewer shares than the threshold")
+		}
+		// a swapped word is caught by the checksum
+		words := strings.Fields(shares[1])
+		words[3], words[4] = words[4], words[3]
+		if _, err := CombineMnemonic([]string{shares[0], strings.Join(words, " ")}); err == nil {
+			t.Fatal("a corrupted share is combined")
+		}
+		// shares of different splits aren't combined
+		others, _ := SplitMnemonic(mnemonic, 3, 2)
+		if _, err := CombineMnemonic([]string{shares[0], others[1]}); err == nil {
+			t.Fatal("shares of different splits are combined")
+		}
+	}
+
+	// a mnemonic is recovered in its language
+	entropy := bytes.Repeat([]byte{0x7f}, 16)
+	for _, language := range []string{bip39.Japanese, bip39.Spanish, bip39.ChineseTraditional} {
+		mnemonic, err := bip39.NewMnemonicInLanguage(entropy, language)
+		if err != nil {
+			t.Fatal(err)
+		}
+		shares, err := SplitMnemonic(mnemonic, 3, 2)
+		if err != nil {
+			t.Fatal(err)
+		}
+		recovered, err := CombineMnemonic([]string{shares[2], shares[0]})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if recovered != mnemonic {
+			t.Fatalf("unexpected %s mnemonic recovered: %s", language, recovered)
+		}
+	}
+
+	if _, err := SplitMnemonic("total lottery arena", 3, 2); err == nil {
+		t.Fatal("an invalid mnemonic is split")
+	}
+}
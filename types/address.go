@@ -11,8 +11,7 @@ import (
 	"fmt"
 
 	"github.com/tendermint/tendermint/crypto"
-	//"github.com/tendermint/tendermint/crypto/encoding/amino"
-	//
+	cryptoAmino "github.com/tendermint/tendermint/crypto/encoding/amino"
 	"github.com/tendermint/tendermint/libs/bech32"
 )
 
@@ -58,6 +57,11 @@ type Address interface {
 	Format(s fmt.State, verb rune)
 }
 
+// Ensure that different address types implement the interface
+var _ Address = AccAddress{}
+var _ Address = ValAddress{}
+var _ Address = ConsAddress{}
+
 
 
 // AccAddress a wrapper around bytes meant to represent an account address.
@@ -186,6 +190,320 @@ func (aa AccAddress) Format(s fmt.State, verb rune) {
 	}
 }
 
+// ValAddress defines a wrapper around bytes meant to present a validator's
+// operator. When marshaled to a string or JSON, it uses Bech32.
+type ValAddress []byte
+
+// ValAddressFromHex creates a ValAddress from a hex string.
+func ValAddressFromHex(address string) (addr ValAddress, err error) {
+	if len(address) == 0 {
+		return addr, errors.New("decoding Bech32 address failed: must provide an address")
+	}
+
+	bz, err := hex.DecodeString(address)
+	if err != nil {
+		return nil, err
+	}
+
+	return ValAddress(bz), nil
+}
+
+// ValAddressFromBech32 creates a ValAddress from a Bech32 string.
+func ValAddressFromBech32(address string) (addr ValAddress, err error) {
+	if len(strings.TrimSpace(address)) == 0 {
+		return ValAddress{}, nil
+	}
+
+	bech32PrefixValAddr := GetConfig().GetBech32ValidatorAddrPrefix()
+
+	bz, err := GetFromBech32(address, bech32PrefixValAddr)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(bz) != AddrLen {
+		return nil, errors.New("Incorrect address length")
+	}
+
+	return ValAddress(bz), nil
+}
+
+// Returns boolean for whether two ValAddresses are Equal
+func (va ValAddress) Equals(va2 Address) bool {
+	if va.Empty() && va2.Empty() {
+		return true
+	}
+
+	return bytes.Equal(va.Bytes(), va2.Bytes())
+}
+
+// Returns boolean for whether an ValAddress is empty
+func (va ValAddress) Empty() bool {
+	if va == nil {
+		return true
+	}
+
+	va2 := ValAddress{}
+	return bytes.Equal(va.Bytes(), va2.Bytes())
+}
+
+// Marshal returns the raw address bytes. It is needed for protobuf
+// compatibility.
+func (va ValAddress) Marshal() ([]byte, error) {
+	return va, nil
+}
+
+// Unmarshal sets the address to the given data. It is needed for protobuf
+// compatibility.
+func (va *ValAddress) Unmarshal(data []byte) error {
+	*va = data
+	return nil
+}
+
+// MarshalJSON marshals to JSON using Bech32.
+func (va ValAddress) MarshalJSON() ([]byte, error) {
+	return json.Marshal(va.String())
+}
+
+// UnmarshalJSON unmarshals from JSON assuming Bech32 encoding.
+func (va *ValAddress) UnmarshalJSON(data []byte) error {
+	var s string
+
+	err := json.Unmarshal(data, &s)
+	if err != nil {
+		return err
+	}
+
+	va2, err := ValAddressFromBech32(s)
+	if err != nil {
+		return err
+	}
+
+	*va = va2
+	return nil
+}
+
+// Bytes returns the raw address bytes.
+func (va ValAddress) Bytes() []byte {
+	return va
+}
+
+// String implements the Stringer interface.
+func (va ValAddress) String() string {
+	if va.Empty() {
+		return ""
+	}
+
+	bech32PrefixValAddr := GetConfig().GetBech32ValidatorAddrPrefix()
+
+	bech32Addr, err := bech32.ConvertAndEncode(bech32PrefixValAddr, va.Bytes())
+	if err != nil {
+		panic(err)
+	}
+
+	return bech32Addr
+}
+
+// Format implements the fmt.Formatter interface.
+// nolint: errcheck
+func (va ValAddress) Format(s fmt.State, verb rune) {
+	switch verb {
+	case 's':
+		s.Write([]byte(va.String()))
+	case 'p':
+		s.Write([]byte(fmt.Sprintf("%p", va)))
+	default:
+		s.Write([]byte(fmt.Sprintf("%X", []byte(va))))
+	}
+}
+
+// ConsAddress defines a wrapper around bytes meant to present a consensus node.
+// When marshaled to a string or JSON, it uses Bech32.
+type ConsAddress []byte
+
+// ConsAddressFromHex creates a ConsAddress from a hex string.
+func ConsAddressFromHex(address string) (addr ConsAddress, err error) {
+	if len(address) == 0 {
+		return addr, errors.New("decoding Bech32 address failed: must provide an address")
+	}
+
+	bz, err := hex.DecodeString(address)
+	if err != nil {
+		return nil, err
+	}
+
+	return ConsAddress(bz), nil
+}
+
+// ConsAddressFromBech32 creates a ConsAddress from a Bech32 string.
+func ConsAddressFromBech32(address string) (addr ConsAddress, err error) {
+	if len(strings.TrimSpace(address)) == 0 {
+		return ConsAddress{}, nil
+	}
+
+	bech32PrefixConsAddr := GetConfig().GetBech32ConsensusAddrPrefix()
+
+	bz, err := GetFromBech32(address, bech32PrefixConsAddr)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(bz) != AddrLen {
+		return nil, errors.New("Incorrect address length")
+	}
+
+	return ConsAddress(bz), nil
+}
+
+// GetConsAddress returns the consensus address of the consensus public key of a node
+func GetConsAddress(pubkey crypto.PubKey) ConsAddress {
+	return ConsAddress(pubkey.Address())
+}
+
+// Returns boolean for whether two ConsAddress are Equal
+func (ca ConsAddress) Equals(ca2 Address) bool {
+	if ca.Empty() && ca2.Empty() {
+		return true
+	}
+
+	return bytes.Equal(ca.Bytes(), ca2.Bytes())
+}
+
+// Returns boolean for whether an ConsAddress is empty
+func (ca ConsAddress) Empty() bool {
+	if ca == nil {
+		return true
+	}
+
+	ca2 := ConsAddress{}
+	return bytes.Equal(ca.Bytes(), ca2.Bytes())
+}
+
+// Marshal returns the raw address bytes. It is needed for protobuf
+// compatibility.
+func (ca ConsAddress) Marshal() ([]byte, error) {
+	return ca, nil
+}
+
+// Unmarshal sets the address to the given data. It is needed for protobuf
+// compatibility.
+func (ca *ConsAddress) Unmarshal(data []byte) error {
+	*ca = data
+	return nil
+}
+
+// MarshalJSON marshals to JSON using Bech32.
+func (ca ConsAddress) MarshalJSON() ([]byte, error) {
+	return json.Marshal(ca.String())
+}
+
+// UnmarshalJSON unmarshals from JSON assuming Bech32 encoding.
+func (ca *ConsAddress) UnmarshalJSON(data []byte) error {
+	var s string
+
+	err := json.Unmarshal(data, &s)
+	if err != nil {
+		return err
+	}
+
+	ca2, err := ConsAddressFromBech32(s)
+	if err != nil {
+		return err
+	}
+
+	*ca = ca2
+	return nil
+}
+
+// Bytes returns the raw address bytes.
+func (ca ConsAddress) Bytes() []byte {
+	return ca
+}
+
+// String implements the Stringer interface.
+func (ca ConsAddress) String() string {
+	if ca.Empty() {
+		return ""
+	}
+
+	bech32PrefixConsAddr := GetConfig().GetBech32ConsensusAddrPrefix()
+
+	bech32Addr, err := bech32.ConvertAndEncode(bech32PrefixConsAddr, ca.Bytes())
+	if err != nil {
+		panic(err)
+	}
+
+	return bech32Addr
+}
+
+// Format implements the fmt.Formatter interface.
+// nolint: errcheck
+func (ca ConsAddress) Format(s fmt.State, verb rune) {
+	switch verb {
+	case 's':
+		s.Write([]byte(ca.String()))
+	case 'p':
+		s.Write([]byte(fmt.Sprintf("%p", ca)))
+	default:
+		s.Write([]byte(fmt.Sprintf("%X", []byte(ca))))
+	}
+}
+
+// ToValAddress returns the validator operator address of the same key as the account address
+func (aa AccAddress) ToValAddress() ValAddress {
+	return ValAddress(aa)
+}
+
+// ToConsAddress returns the consensus address of the same key as the account address
+func (aa AccAddress) ToConsAddress() ConsAddress {
+	return ConsAddress(aa)
+}
+
+// ToAccAddress returns the account address of the same key as the validator operator address
+func (va ValAddress) ToAccAddress() AccAddress {
+	return AccAddress(va)
+}
+
+// ToConsAddress returns the consensus address of the same key as the validator operator address
+func (va ValAddress) ToConsAddress() ConsAddress {
+	return ConsAddress(va)
+}
+
+// ToAccAddress returns the account address of the same key as the consensus address
+func (ca ConsAddress) ToAccAddress() AccAddress {
+	return AccAddress(ca)
+}
+
+// ToValAddress returns the validator operator address of the same key as the consensus address
+func (ca ConsAddress) ToValAddress() ValAddress {
+	return ValAddress(ca)
+}
+
+// ConvertBech32Address converts a Bech32 address of any of the account, the validator operator
+// and the consensus forms, e.g. okchain1..., okchainvaloper1... or okchainvalcons1..., to the form
+// of the prefix
+func ConvertBech32Address(address, prefix string) (string, error) {
+	config := GetConfig()
+	hrp, bz, err := bech32.DecodeAndConvert(address)
+	if err != nil {
+		return "", err
+	}
+	switch hrp {
+	case config.GetBech32AccountAddrPrefix(), config.GetBech32ValidatorAddrPrefix(), config.GetBech32ConsensusAddrPrefix():
+	default:
+		return "", fmt.Errorf("invalid Bech32 prefix of an address: %s", hrp)
+	}
+	switch prefix {
+	case config.GetBech32AccountAddrPrefix(), config.GetBech32ValidatorAddrPrefix(), config.GetBech32ConsensusAddrPrefix():
+	default:
+		return "", fmt.Errorf("invalid Bech32 prefix of an address: %s", prefix)
+	}
+	if len(bz) != AddrLen {
+		return "", errors.New("Incorrect address length")
+	}
+	return bech32.ConvertAndEncode(prefix, bz)
+}
+
 // Bech32ifyAccPub returns a Bech32 encoded string containing the
 // Bech32PrefixAccPub prefix for a given account PubKey.
 func Bech32ifyAccPub(pub crypto.PubKey) (string, error) {
@@ -203,6 +521,17 @@ func MustBech32ifyAccPub(pub crypto.PubKey) string {
 	return enc
 }
 
+// GetAccPubKeyBech32 creates a PubKey for an account with a given public key string using the
+// Bech32 Bech32PrefixAccPub prefix.
+func GetAccPubKeyBech32(pubkey string) (pk crypto.PubKey, err error) {
+	bz, err := GetFromBech32(pubkey, GetConfig().GetBech32AccountPubPrefix())
+	if err != nil {
+		return nil, err
+	}
+
+	return cryptoAmino.PubKeyFromBytes(bz)
+}
+
 // GetFromBech32 decodes a bytestring from a Bech32 encoded string.
 func GetFromBech32(bech32str, prefix string) ([]byte, error) {
 	if len(bech32str) == 0 {
@@ -221,6 +550,37 @@ func GetFromBech32(bech32str, prefix string) ([]byte, error) {
 	return bz, nil
 }
 
+// Bech32ifyValPub returns a Bech32 encoded string containing the
+// Bech32PrefixValPub prefix for a given validator operator's PubKey.
+func Bech32ifyValPub(pub crypto.PubKey) (string, error) {
+	bech32PrefixValPub := GetConfig().GetBech32ValidatorPubPrefix()
+	return bech32.ConvertAndEncode(bech32PrefixValPub, pub.Bytes())
+}
+
+// GetValPubKeyBech32 creates a PubKey for a validator's operator with a given public key string
+// using the Bech32 Bech32PrefixValPub prefix.
+func GetValPubKeyBech32(pubkey string) (pk crypto.PubKey, err error) {
+	bz, err := GetFromBech32(pubkey, GetConfig().GetBech32ValidatorPubPrefix())
+	if err != nil {
+		return nil, err
+	}
+
+	return cryptoAmino.PubKeyFromBytes(bz)
+}
+
+// GetConsPubKeyBech32 creates a PubKey for a consensus node with a given public key string using
+// the Bech32 Bech32PrefixConsPub prefix.
+func GetConsPubKeyBech32(pubkey string) (pk crypto.PubKey, err error) {
+	bz, err := GetFromBech32(pubkey, GetConfig().GetBech32ConsensusPubPrefix())
+	if err != nil {
+		return nil, err
+	}
+
+	return cryptoAmino.PubKeyFromBytes(bz)
+}
+
+// Bech32ifyConsPub returns a Bech32 encoded string containing the
+// Bech32PrefixConsPub prefix for a given consensus node's PubKey.
 func Bech32ifyConsPub(pub crypto.PubKey) (string, error) {
 	bech32PrefixConsPub := GetConfig().GetBech32ConsensusPubPrefix()
 	return bech32.ConvertAndEncode(bech32PrefixConsPub, pub.Bytes())
@@ -0,0 +1,82 @@
+package types
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/tendermint/tendermint/crypto/ed25519"
+	"github.com/tendermint/tendermint/crypto/secp256k1"
+)
+
+func TestAddressConversions(t *testing.T) {
+	accAddr := AccAddress(secp256k1.GenPrivKey().PubKey().Address())
+	valAddr := accAddr.ToValAddress()
+	consAddr := accAddr.ToConsAddress()
+	if !strings.HasPrefix(valAddr.String(), Bech32PrefixValAddr+"1") || !strings.HasPrefix(consAddr.String(), Bech32PrefixConsAddr+"1") {
+		t.Fatalf("unexpected addresses: %s %s", valAddr, consAddr)
+	}
+	if !valAddr.ToAccAddress().Equals(accAddr) || !consAddr.ToValAddress().Equals(valAddr) || !valAddr.ToConsAddress().Equals(consAddr) {
+		t.Fatal("the conversions don't keep the key")
+	}
+
+	// the bech32 strings are converted and parsed back
+	converted, err := ConvertBech32Address(accAddr.String(), Bech32PrefixValAddr)
+	if err != nil {
+		t.Fatal(err)
+	}
+	parsedVal, err := ValAddressFromBech32(converted)
+	if err != nil || !parsedVal.Equals(valAddr) {
+		t.Fatalf("unexpected validator address parsed from %s: %s %v", converted, parsedVal, err)
+	}
+	converted, err = ConvertBech32Address(consAddr.String(), Bech32PrefixAccAddr)
+	if err != nil || converted != accAddr.String() {
+		t.Fatalf("unexpected account address converted: %s %v", converted, err)
+	}
+	if _, err := ValAddressFromBech32(accAddr.String()); err == nil {
+		t.Fatal("an account address is parsed as a validator address")
+	}
+	if _, err := ConvertBech32Address(MustBech32ifyAccPub(secp256k1.GenPrivKey().PubKey()), Bech32PrefixAccAddr); err == nil {
+		t.Fatal("a public key is converted as an address")
+	}
+
+	// JSON
+	bz, err := json.Marshal(struct {
+		Val  ValAddress  `json:"val"`
+		Cons ConsAddress `json:"cons"`
+	}{valAddr, consAddr})
+	if err != nil {
+		t.Fatal(err)
+	}
+	var decoded struct {
+		Val  ValAddress  `json:"val"`
+		Cons ConsAddress `json:"cons"`
+	}
+	if err := json.Unmarshal(bz, &decoded); err != nil {
+		t.Fatal(err)
+	}
+	if !decoded.Val.Equals(valAddr) || !decoded.Cons.Equals(consAddr) || !strings.Contains(string(bz), valAddr.String()) {
+		t.Fatalf("unexpected JSON: %s", bz)
+	}
+
+	// the consensus address and public key of a node
+	consPub := ed25519.GenPrivKey().PubKey()
+	bech32Pub, err := Bech32ifyConsPub(consPub)
+	if err != nil {
+		t.Fatal(err)
+	}
+	parsedPub, err := GetConsPubKeyBech32(bech32Pub)
+	if err != nil || !parsedPub.Equals(consPub) {
+		t.Fatalf("unexpected consensus public key parsed from %s", bech32Pub)
+	}
+	if !GetConsAddress(consPub).Equals(ConsAddress(consPub.Address())) {
+		t.Fatal("unexpected consensus address of the public key")
+	}
+	valPub, err := Bech32ifyValPub(consPub)
+	if err != nil || !strings.HasPrefix(valPub, Bech32PrefixValPub) {
+		t.Fatalf("unexpected validator public key: %s", valPub)
+	}
+	if parsedPub, err := GetValPubKeyBech32(valPub); err != nil || !parsedPub.Equals(consPub) {
+		t.Fatalf("unexpected validator public key parsed from %s", valPub)
+	}
+}
@@ -2,11 +2,50 @@ package types
 
 import (
 	"encoding/json"
+	"errors"
 	"fmt"
+	"regexp"
 	"sort"
 	"strings"
+	"sync"
 )
 
+// DefaultCoinDenomRegex is the regex of the denoms of OKChain: a lowercase symbol of up to 6
+// characters, which may be suffixed by a dash and 3 more characters like xxb-127
+const DefaultCoinDenomRegex = `[a-z][a-z0-9]{0,5}(\-[a-z0-9]{3})?`
+
+var (
+	reDnmMtx    sync.RWMutex
+	reDnmString = DefaultCoinDenomRegex
+	reDnm       = regexp.MustCompile(fmt.Sprintf(`^%s$`, reDnmString))
+)
+
+// SetCoinDenomRegex sets the regex of the valid denoms, e.g. `[a-z][a-z0-9]{2,15}` for a chain with
+// longer symbols. The regex isn't anchored, since the coin parsers embed it after the amount.
+func SetCoinDenomRegex(regex string) error {
+	re, err := regexp.Compile(fmt.Sprintf(`^%s$`, regex))
+	if err != nil {
+		return fmt.Errorf("invalid coin denom regex %s: %s", regex, err)
+	}
+
+	reDnmMtx.Lock()
+	defer reDnmMtx.Unlock()
+	reDnmString, reDnm = regex, re
+	return nil
+}
+
+// CoinDenomRegex returns the regex of the valid denoms
+func CoinDenomRegex() string {
+	reDnmMtx.RLock()
+	defer reDnmMtx.RUnlock()
+	return reDnmString
+}
+
+// ValidateDenom checks the denom against the regex of the valid denoms
+func ValidateDenom(denom string) error {
+	return validateDenom(denom)
+}
+
 type Coin struct {
 	Denom string `json:"denom"`
 
@@ -37,6 +76,48 @@ func (coin Coin) IsPositive() bool {
 	return coin.Amount.Sign() == 1
 }
 
+// IsNegative returns whether the amount of the coin is negative, which only happens to the results
+// of SafeSub
+func (coin Coin) IsNegative() bool {
+	return coin.Amount.Sign() == -1
+}
+
+// IsEqual returns whether two coins of the same denom have the same amount
+func (coin Coin) IsEqual(other Coin) bool {
+	mustSameDenom(coin.Denom, other.Denom)
+	return coin.Amount.Equal(other.Amount)
+}
+
+// IsGTE returns whether the amount of the coin is greater than or equal to the one of the other
+// coin of the same denom
+func (coin Coin) IsGTE(other Coin) bool {
+	mustSameDenom(coin.Denom, other.Denom)
+	return coin.Amount.GTE(other.Amount)
+}
+
+// IsLT returns whether the amount of the coin is less than the one of the other coin of the same
+// denom
+func (coin Coin) IsLT(other Coin) bool {
+	mustSameDenom(coin.Denom, other.Denom)
+	return coin.Amount.LT(other.Amount)
+}
+
+// Add adds the amounts of two coins of the same denom
+func (coin Coin) Add(coinB Coin) Coin {
+	mustSameDenom(coin.Denom, coinB.Denom)
+	return Coin{coin.Denom, coin.Amount.Add(coinB.Amount)}
+}
+
+// Sub subtracts the amount of a coin of the same denom. It panics if the result is negative.
+func (coin Coin) Sub(coinB Coin) Coin {
+	mustSameDenom(coin.Denom, coinB.Denom)
+	res := Coin{coin.Denom, coin.Amount.Sub(coinB.Amount)}
+	if res.IsNegative() {
+		panic(fmt.Errorf("negative coin amount: %s - %s", coin, coinB))
+	}
+	return res
+}
+
 func (coin Coin) String() string {
 	dec := NewDecFromIntWithPrec(coin.Amount, Precision)
 	return fmt.Sprintf("%s%v", dec, coin.Denom)
@@ -152,6 +233,137 @@ func (coins Coins) IsAllPositive() bool {
 	return true
 }
 
+// Empty returns whether there are no coins
+func (coins Coins) Empty() bool {
+	return len(coins) == 0
+}
+
+// IsZero returns whether all the amounts of the coins are zero
+func (coins Coins) IsZero() bool {
+	for _, coin := range coins {
+		if !coin.IsZero() {
+			return false
+		}
+	}
+	return true
+}
+
+// IsAnyNegative returns whether any amount of the coins is negative
+func (coins Coins) IsAnyNegative() bool {
+	for _, coin := range coins {
+		if coin.IsNegative() {
+			return true
+		}
+	}
+	return false
+}
+
+// IsEqual returns whether the coins have the same amounts of the same denoms, the missing denoms
+// being zero
+func (coins Coins) IsEqual(coinsB Coins) bool {
+	for _, denom := range unionDenoms(coins.denoms(), coinsB.denoms()) {
+		if !coins.AmountOf(denom).Equal(coinsB.AmountOf(denom)) {
+			return false
+		}
+	}
+	return true
+}
+
+// AmountOf returns the amount of the denom, which is zero if the coins don't hold it
+func (coins Coins) AmountOf(denom string) Int {
+	for _, coin := range coins {
+		if coin.Denom == denom {
+			return coin.Amount
+		}
+	}
+	return ZeroInt()
+}
+
+// Add adds the coins denom by denom. The result is sorted and has no zero coins.
+func (coins Coins) Add(coinsB Coins) Coins {
+	return coins.combine(coinsB, func(a, b Int) Int { return a.Add(b) })
+}
+
+// Sub subtracts the coins denom by denom. It panics if any amount of the result is negative.
+func (coins Coins) Sub(coinsB Coins) Coins {
+	diff, hasNeg := coins.SafeSub(coinsB)
+	if hasNeg {
+		panic(fmt.Errorf("negative coin amount: %s - %s", coins, coinsB))
+	}
+	return diff
+}
+
+// SafeSub subtracts the coins denom by denom and returns whether any amount of the result is
+// negative instead of panicking, e.g. to compute the shortfall of a balance
+func (coins Coins) SafeSub(coinsB Coins) (Coins, bool) {
+	diff := coins.combine(coinsB, func(a, b Int) Int { return a.Sub(b) })
+	return diff, diff.IsAnyNegative()
+}
+
+// IsAllGTE returns whether the amount of every denom of coinsB is covered by the coins
+func (coins Coins) IsAllGTE(coinsB Coins) bool {
+	for _, coin := range coinsB {
+		if coins.AmountOf(coin.Denom).LT(coin.Amount) {
+			return false
+		}
+	}
+	return true
+}
+
+// Intersect returns the lesser amount of each denom held by both coins
+func (coins Coins) Intersect(coinsB Coins) Coins {
+	var res Coins
+	for _, coin := range coins {
+		for _, coinB := range coinsB {
+			if coin.Denom == coinB.Denom {
+				res = append(res, Coin{coin.Denom, MinInt(coin.Amount, coinB.Amount)})
+			}
+		}
+	}
+	return removeZeroCoins(res).Sort()
+}
+
+// Min returns the lesser amount of each denom, the missing denoms being zero
+func (coins Coins) Min(coinsB Coins) Coins {
+	return coins.combine(coinsB, MinInt)
+}
+
+// Max returns the greater amount of each denom, the missing denoms being zero
+func (coins Coins) Max(coinsB Coins) Coins {
+	return coins.combine(coinsB, MaxInt)
+}
+
+// ToDecCoins converts the coins to DecCoins. Both have Precision decimal places, so nothing is
+// rounded.
+func (coins Coins) ToDecCoins() DecCoins {
+	res := make(DecCoins, 0, len(coins))
+	for _, coin := range coins {
+		res = append(res, NewDecCoinFromCoin(coin))
+	}
+	return res
+}
+
+func (coins Coins) denoms() []string {
+	denoms := make([]string, len(coins))
+	for i, coin := range coins {
+		denoms[i] = coin.Denom
+	}
+	return denoms
+}
+
+// combine applies the operation to the amounts of each denom of both coins, the missing denoms
+// being zero, and drops the zero results
+func (coins Coins) combine(coinsB Coins, op func(a, b Int) Int) Coins {
+	res := Coins{}
+	for _, denom := range unionDenoms(coins.denoms(), coinsB.denoms()) {
+		amount := op(coins.AmountOf(denom), coinsB.AmountOf(denom))
+		if !amount.IsZero() {
+			res = append(res, Coin{denom, amount})
+		}
+	}
+	return res
+}
+
 func (coins Coins) String() string {
 	if len(coins) == 0 {
 		return ""
@@ -165,9 +377,11 @@ func (coins Coins) String() string {
 }
 
 func validateDenom(denom string) error {
-	//if !reDnm.MatchString(denom) {
-	//	return errors.New("illegal characters")
-	//}
+	reDnmMtx.RLock()
+	defer reDnmMtx.RUnlock()
+	if !reDnm.MatchString(denom) {
+		return errors.New("illegal characters")
+	}
 	return nil
 }
 
@@ -177,6 +391,26 @@ func mustValidateDenom(denom string) {
 	}
 }
 
+func mustSameDenom(denomA, denomB string) {
+	if denomA != denomB {
+		panic(fmt.Errorf("coin denoms differ: %s, %s", denomA, denomB))
+	}
+}
+
+// unionDenoms returns the sorted denoms of both lists without duplicates
+func unionDenoms(denomsA, denomsB []string) []string {
+	denoms := make([]string, 0, len(denomsA)+len(denomsB))
+	seen := make(map[string]bool)
+	for _, denom := range append(append([]string{}, denomsA...), denomsB...) {
+		if !seen[denom] {
+			seen[denom] = true
+			denoms = append(denoms, denom)
+		}
+	}
+	sort.Strings(denoms)
+	return denoms
+}
+
 func removeZeroCoins(coins Coins) Coins {
 	i, l := 0, len(coins)
 	for i < l {
@@ -0,0 +1,111 @@
+package types
+
+import (
+	"testing"
+)
+
+// coins returns the coins of the amounts in the smallest unit by the denoms
+func coins(amounts map[string]int64) Coins {
+	var res Coins
+	for denom, amount := range amounts {
+		res = append(res, Coin{denom, NewInt(amount)})
+	}
+	return res.Sort()
+}
+
+func TestCoinsArithmetic(t *testing.T) {
+	a := coins(map[string]int64{"okt": 300, "xxb": 100})
+	b := coins(map[string]int64{"okb": 50, "okt": 100})
+
+	if sum := a.Add(b); sum.String() != "0.00000050okb,0.00000400okt,0.00000100xxb" || !sum.IsValid() {
+		t.Fatalf("unexpected sum: %s", sum)
+	}
+	if diff := a.Sub(coins(map[string]int64{"okt": 300, "xxb": 1})); diff.String() != "0.00000099xxb" {
+		t.Fatalf("unexpected difference: %s", diff)
+	}
+	diff, hasNeg := a.SafeSub(b)
+	if !hasNeg || diff.AmountOf("okb").Int64() != -50 || diff.AmountOf("okt").Int64() != 200 {
+		t.Fatalf("unexpected shortfall: %s", diff)
+	}
+	func() {
+		defer func() {
+			if recover() == nil {
+				t.Fatal("a negative difference doesn't panic")
+			}
+		}()
+		a.Sub(b)
+	}()
+
+	if a.IsAllGTE(b) || !a.IsAllGTE(coins(map[string]int64{"okt": 300})) || !a.IsAllGTE(nil) {
+		t.Fatal("unexpected comparison of the coins")
+	}
+	if a.AmountOf("okb").Int64() != 0 || a.AmountOf("xxb").Int64() != 100 {
+		t.Fatal("unexpected amounts of the coins")
+	}
+	if intersect := a.Intersect(b); intersect.String() != "0.00000100okt" {
+		t.Fatalf("unexpected intersection: %s", intersect)
+	}
+	if max := a.Max(b); !max.IsEqual(coins(map[string]int64{"okb": 50, "okt": 300, "xxb": 100})) {
+		t.Fatalf("unexpected max: %s", max)
+	}
+	if min := a.Min(b); !min.IsEqual(a.Intersect(b)) {
+		t.Fatalf("unexpected min: %s", min)
+	}
+	// the inputs are kept
+	if a.String() != "0.00000300okt,0.00000100xxb" {
+		t.Fatalf("the coins are modified: %s", a)
+	}
+}
+
+func TestDecCoinsArithmetic(t *testing.T) {
+	a := NewDecCoins(NewDecCoinFromDec("okt", MustNewDecFromStr("1.5")), NewDecCoinFromDec("xxb", NewDec(2)))
+	b := NewDecCoins(NewDecCoinFromDec("okt", MustNewDecFromStr("0.5")))
+
+	if sum := a.Add(b); sum.String() != "2.00000000okt,2.00000000xxb" {
+		t.Fatalf("unexpected sum: %s", sum)
+	}
+	if diff := a.Sub(b); diff.String() != "1.00000000okt,2.00000000xxb" {
+		t.Fatalf("unexpected difference: %s", diff)
+	}
+	if _, hasNeg := b.SafeSub(a); !hasNeg {
+		t.Fatal("no shortfall")
+	}
+	if !a.IsAllGTE(b) || b.IsAllGTE(a) {
+		t.Fatal("unexpected comparison of the coins")
+	}
+	if intersect := a.Intersect(b); !intersect.IsEqual(b) {
+		t.Fatalf("unexpected intersection: %s", intersect)
+	}
+	if max := a.Max(b); !max.IsEqual(a) {
+		t.Fatalf("unexpected max: %s", max)
+	}
+
+	// the rounding of the products and the quotients is explicit
+	third := a.QuoDec(NewDec(3))
+	if third.String() != "0.50000000okt,0.66666667xxb" {
+		t.Fatalf("unexpected quotient: %s", third)
+	}
+	if truncated := a.QuoDecTruncate(NewDec(3)); truncated.String() != "0.50000000okt,0.66666666xxb" {
+		t.Fatalf("unexpected truncated quotient: %s", truncated)
+	}
+	// half of the smallest unit is rounded to even, so the zero product is dropped
+	if product := b.MulDec(MustNewDecFromStr("0.00000001")); !product.Empty() {
+		t.Fatalf("unexpected product: %s", product)
+	}
+	if product := a.MulDecTruncate(MustNewDecFromStr("0.1")); product.String() != "0.15000000okt,0.20000000xxb" {
+		t.Fatalf("unexpected truncated product: %s", product)
+	}
+
+	// the conversions to Coins
+	truncated, change := third.TruncateDecimal(4)
+	if truncated.String() != "0.50000000okt,0.66660000xxb" || change.String() != "0.00006667xxb" {
+		t.Fatalf("unexpected truncation: %s, %s", truncated, change)
+	}
+	if rounded := third.RoundUpDecimal(4); rounded.String() != "0.50000000okt,0.66670000xxb" {
+		t.Fatalf("unexpected rounding: %s", rounded)
+	}
+	converted, change := third.TruncateDecimal(Precision)
+	if len(change) != 0 || !converted.ToDecCoins().IsEqual(third) {
+		t.Fatalf("unexpected conversion of %s: %s, %s", third, converted, change)
+	}
+}
@@ -48,3 +48,18 @@ func (config *Config) GetBech32AccountPubPrefix() string {
 func (config *Config) GetBech32ConsensusPubPrefix() string {
 	return config.bech32AddressPrefix["consensus_pub"]
 }
+
+// GetBech32ValidatorAddrPrefix returns the Bech32 prefix for validator address
+func (config *Config) GetBech32ValidatorAddrPrefix() string {
+	return config.bech32AddressPrefix["validator_addr"]
+}
+
+// GetBech32ConsensusAddrPrefix returns the Bech32 prefix for consensus node address
+func (config *Config) GetBech32ConsensusAddrPrefix() string {
+	return config.bech32AddressPrefix["consensus_addr"]
+}
+
+// GetBech32ValidatorPubPrefix returns the Bech32 prefix for validator public key
+func (config *Config) GetBech32ValidatorPubPrefix() string {
+	return config.bech32AddressPrefix["validator_pub"]
+}
@@ -1,8 +1,367 @@
 package types
 
+import (
+	"fmt"
+	"math/big"
+	"sort"
+	"strings"
+)
+
 type DecCoins []DecCoin
 
 type DecCoin struct {
 	Denom  string `json:"denom"`
 	Amount Dec    `json:"amount"`
-}
\ No newline at end of file
+}
+
+// NewDecCoinFromDec creates a DecCoin of a non-negative decimal amount
+func NewDecCoinFromDec(denom string, amount Dec) DecCoin {
+	mustValidateDenom(denom)
+
+	if amount.IsNegative() {
+		panic(fmt.Errorf("negative decimal coin amount: %v", amount))
+	}
+
+	return DecCoin{
+		Denom:  denom,
+		Amount: amount,
+	}
+}
+
+// NewDecCoinFromCoin converts a coin, whose amount is in the smallest unit, to a DecCoin
+func NewDecCoinFromCoin(coin Coin) DecCoin {
+	return DecCoin{
+		Denom:  coin.Denom,
+		Amount: NewDecFromIntWithPrec(coin.Amount, Precision),
+	}
+}
+
+func (coin DecCoin) IsZero() bool {
+	return coin.Amount.IsZero()
+}
+
+func (coin DecCoin) IsPositive() bool {
+	return coin.Amount.IsPositive()
+}
+
+func (coin DecCoin) IsNegative() bool {
+	return coin.Amount.IsNegative()
+}
+
+// IsEqual returns whether two coins of the same denom have the same amount
+func (coin DecCoin) IsEqual(other DecCoin) bool {
+	mustSameDenom(coin.Denom, other.Denom)
+	return coin.Amount.Equal(other.Amount)
+}
+
+// IsGTE returns whether the amount of the coin is greater than or equal to the one of the other
+// coin of the same denom
+func (coin DecCoin) IsGTE(other DecCoin) bool {
+	mustSameDenom(coin.Denom, other.Denom)
+	return coin.Amount.GTE(other.Amount)
+}
+
+// IsLT returns whether the amount of the coin is less than the one of the other coin of the same
+// denom
+func (coin DecCoin) IsLT(other DecCoin) bool {
+	mustSameDenom(coin.Denom, other.Denom)
+	return coin.Amount.LT(other.Amount)
+}
+
+// Add adds the amounts of two coins of the same denom
+func (coin DecCoin) Add(coinB DecCoin) DecCoin {
+	mustSameDenom(coin.Denom, coinB.Denom)
+	return DecCoin{coin.Denom, coin.Amount.Add(coinB.Amount)}
+}
+
+// Sub subtracts the amount of a coin of the same denom. It panics if the result is negative.
+func (coin DecCoin) Sub(coinB DecCoin) DecCoin {
+	mustSameDenom(coin.Denom, coinB.Denom)
+	res := DecCoin{coin.Denom, coin.Amount.Sub(coinB.Amount)}
+	if res.IsNegative() {
+		panic(fmt.Errorf("negative decimal coin amount: %s - %s", coin, coinB))
+	}
+	return res
+}
+
+// TruncateDecimal truncates the amount to prec decimal places, e.g. the quantity digits of a
+// product, and returns it as a coin along with the truncated change
+// CONTRACT: 0 <= prec <= Precision
+func (coin DecCoin) TruncateDecimal(prec int64) (Coin, DecCoin) {
+	truncated := new(big.Int).Quo(coin.Amount.Int, precisionMultiplier(prec))
+	truncated.Mul(truncated, precisionMultiplier(prec))
+	return NewCoin(coin.Denom, NewIntFromBigInt(truncated)),
+		DecCoin{coin.Denom, coin.Amount.Sub(Dec{truncated})}
+}
+
+// RoundUpDecimal rounds the amount up to prec decimal places and returns it as a coin, e.g. a fee
+// which has to be covered fully
+// CONTRACT: 0 <= prec <= Precision
+func (coin DecCoin) RoundUpDecimal(prec int64) Coin {
+	quo, rem := new(big.Int).QuoRem(coin.Amount.Int, precisionMultiplier(prec), new(big.Int))
+	if rem.Sign() > 0 {
+		quo.Add(quo, oneInt)
+	}
+	return NewCoin(coin.Denom, NewIntFromBigInt(quo.Mul(quo, precisionMultiplier(prec))))
+}
+
+func (coin DecCoin) String() string {
+	return fmt.Sprintf("%s%v", coin.Amount, coin.Denom)
+}
+
+// NewDecCoins constructs a new decimal coin set, sorted and without zero coins
+func NewDecCoins(coins ...DecCoin) DecCoins {
+	newCoins := removeZeroDecCoins(DecCoins(coins))
+	if len(newCoins) == 0 {
+		return DecCoins{}
+	}
+
+	newCoins.Sort()
+
+	if !newCoins.IsValid() {
+		panic(fmt.Errorf("invalid decimal coin set: %s", newCoins))
+	}
+
+	return newCoins
+}
+
+// Sort interface
+func (coins DecCoins) Len() int           { return len(coins) }
+func (coins DecCoins) Less(i, j int) bool { return coins[i].Denom < coins[j].Denom }
+func (coins DecCoins) Swap(i, j int)      { coins[i], coins[j] = coins[j], coins[i] }
+
+var _ sort.Interface = DecCoins{}
+
+// Sort is a helper function to sort the set of decimal coins inplace
+func (coins DecCoins) Sort() DecCoins {
+	sort.Sort(coins)
+	return coins
+}
+
+// IsValid asserts the DecCoins are sorted without duplicates, have positive amounts and lower case
+// denoms
+func (coins DecCoins) IsValid() bool {
+	for i, coin := range coins {
+		if err := validateDenom(coin.Denom); err != nil || strings.ToLower(coin.Denom) != coin.Denom {
+			return false
+		}
+		if !coin.IsPositive() {
+			return false
+		}
+		if i > 0 && coin.Denom <= coins[i-1].Denom {
+			return false
+		}
+	}
+	return true
+}
+
+// Empty returns whether there are no coins
+func (coins DecCoins) Empty() bool {
+	return len(coins) == 0
+}
+
+// IsZero returns whether all the amounts of the coins are zero
+func (coins DecCoins) IsZero() bool {
+	for _, coin := range coins {
+		if !coin.IsZero() {
+			return false
+		}
+	}
+	return true
+}
+
+// IsAnyNegative returns whether any amount of the coins is negative
+func (coins DecCoins) IsAnyNegative() bool {
+	for _, coin := range coins {
+		if coin.IsNegative() {
+			return true
+		}
+	}
+	return false
+}
+
+// IsEqual returns whether the coins have the same amounts of the same denoms, the missing denoms
+// being zero
+func (coins DecCoins) IsEqual(coinsB DecCoins) bool {
+	for _, denom := range unionDenoms(coins.denoms(), coinsB.denoms()) {
+		if !coins.AmountOf(denom).Equal(coinsB.AmountOf(denom)) {
+			return false
+		}
+	}
+	return true
+}
+
+// AmountOf returns the amount of the denom, which is zero if the coins don't hold it
+func (coins DecCoins) AmountOf(denom string) Dec {
+	for _, coin := range coins {
+		if coin.Denom == denom {
+			return coin.Amount
+		}
+	}
+	return ZeroDec()
+}
+
+// Add adds the coins denom by denom. The result is sorted and has no zero coins.
+func (coins DecCoins) Add(coinsB DecCoins) DecCoins {
+	return coins.combine(coinsB, Dec.Add)
+}
+
+// Sub subtracts the coins denom by denom. It panics if any amount of the result is negative.
+func (coins DecCoins) Sub(coinsB DecCoins) DecCoins {
+	diff, hasNeg := coins.SafeSub(coinsB)
+	if hasNeg {
+		panic(fmt.Errorf("negative decimal coin amount: %s - %s", coins, coinsB))
+	}
+	return diff
+}
+
+// SafeSub subtracts the coins denom by denom and returns whether any amount of the result is
+// negative instead of panicking
+func (coins DecCoins) SafeSub(coinsB DecCoins) (DecCoins, bool) {
+	diff := coins.combine(coinsB, Dec.Sub)
+	return diff, diff.IsAnyNegative()
+}
+
+// IsAllGTE returns whether the amount of every denom of coinsB is covered by the coins
+func (coins DecCoins) IsAllGTE(coinsB DecCoins) bool {
+	for _, coin := range coinsB {
+		if coins.AmountOf(coin.Denom).LT(coin.Amount) {
+			return false
+		}
+	}
+	return true
+}
+
+// Intersect returns the lesser amount of each denom held by both coins
+func (coins DecCoins) Intersect(coinsB DecCoins) DecCoins {
+	var res DecCoins
+	for _, coin := range coins {
+		for _, coinB := range coinsB {
+			if coin.Denom == coinB.Denom {
+				res = append(res, DecCoin{coin.Denom, MinDec(coin.Amount, coinB.Amount)})
+			}
+		}
+	}
+	return removeZeroDecCoins(res).Sort()
+}
+
+// Min returns the lesser amount of each denom, the missing denoms being zero
+func (coins DecCoins) Min(coinsB DecCoins) DecCoins {
+	return coins.combine(coinsB, MinDec)
+}
+
+// Max returns the greater amount of each denom, the missing denoms being zero
+func (coins DecCoins) Max(coinsB DecCoins) DecCoins {
+	return coins.combine(coinsB, MaxDec)
+}
+
+// MulDec multiplies the amounts by the decimal, rounding them half to even at Precision decimal
+// places
+func (coins DecCoins) MulDec(d Dec) DecCoins {
+	return coins.apply(func(amount Dec) Dec { return amount.Mul(d) })
+}
+
+// MulDecTruncate multiplies the amounts by the decimal, truncating them at Precision decimal places
+func (coins DecCoins) MulDecTruncate(d Dec) DecCoins {
+	return coins.apply(func(amount Dec) Dec { return amount.MulTruncate(d) })
+}
+
+// QuoDec divides the amounts by the decimal, rounding them half to even at Precision decimal
+// places. It panics if the decimal is zero.
+func (coins DecCoins) QuoDec(d Dec) DecCoins {
+	if d.IsZero() {
+		panic("invalid zero decimal")
+	}
+	return coins.apply(func(amount Dec) Dec { return amount.Quo(d) })
+}
+
+// QuoDecTruncate divides the amounts by the decimal, truncating them at Precision decimal places.
+// It panics if the decimal is zero.
+func (coins DecCoins) QuoDecTruncate(d Dec) DecCoins {
+	if d.IsZero() {
+		panic("invalid zero decimal")
+	}
+	return coins.apply(func(amount Dec) Dec { return amount.QuoTruncate(d) })
+}
+
+// TruncateDecimal truncates the amounts to prec decimal places and returns them as coins along
+// with the truncated change. With Precision, it converts the coins to Coins without any change.
+// CONTRACT: 0 <= prec <= Precision, and the amounts aren't negative
+func (coins DecCoins) TruncateDecimal(prec int64) (truncatedCoins Coins, changeCoins DecCoins) {
+	for _, coin := range coins {
+		truncated, change := coin.TruncateDecimal(prec)
+		if !truncated.IsZero() {
+			truncatedCoins = append(truncatedCoins, truncated)
+		}
+		if !change.IsZero() {
+			changeCoins = append(changeCoins, change)
+		}
+	}
+	return truncatedCoins, changeCoins
+}
+
+// RoundUpDecimal rounds the amounts up to prec decimal places and returns them as coins
+// CONTRACT: 0 <= prec <= Precision, and the amounts aren't negative
+func (coins DecCoins) RoundUpDecimal(prec int64) Coins {
+	var res Coins
+	for _, coin := range coins {
+		if rounded := coin.RoundUpDecimal(prec); !rounded.IsZero() {
+			res = append(res, rounded)
+		}
+	}
+	return res
+}
+
+func (coins DecCoins) String() string {
+	if len(coins) == 0 {
+		return ""
+	}
+
+	out := make([]string, len(coins))
+	for i, coin := range coins {
+		out[i] = coin.String()
+	}
+	return strings.Join(out, ",")
+}
+
+func (coins DecCoins) denoms() []string {
+	denoms := make([]string, len(coins))
+	for i, coin := range coins {
+		denoms[i] = coin.Denom
+	}
+	return denoms
+}
+
+// combine applies the operation to the amounts of each denom of both coins, the missing denoms
+// being zero, and drops the zero results
+func (coins DecCoins) combine(coinsB DecCoins, op func(a, b Dec) Dec) DecCoins {
+	res := DecCoins{}
+	for _, denom := range unionDenoms(coins.denoms(), coinsB.denoms()) {
+		amount := op(coins.AmountOf(denom), coinsB.AmountOf(denom))
+		if !amount.IsZero() {
+			res = append(res, DecCoin{denom, amount})
+		}
+	}
+	return res
+}
+
+// apply applies the operation to every amount and drops the zero results
+func (coins DecCoins) apply(op func(amount Dec) Dec) DecCoins {
+	res := DecCoins{}
+	for _, coin := range coins {
+		if amount := op(coin.Amount); !amount.IsZero() {
+			res = append(res, DecCoin{coin.Denom, amount})
+		}
+	}
+	return res
+}
+
+func removeZeroDecCoins(coins DecCoins) DecCoins {
+	res := make(DecCoins, 0, len(coins))
+	for _, coin := range coins {
+		if !coin.IsZero() {
+			res = append(res, coin)
+		}
+	}
+	return res
+}
@@ -0,0 +1,184 @@
+package types
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+)
+
+// CodespaceOrder is the codespace of the errors returned by the order module of OKChain
+const CodespaceOrder CodespaceType = "order"
+
+// codes of the errors returned by the order module of OKChain
+const (
+	CodeProductNotListed CodeType = 1
+	CodeOrderNotExist    CodeType = 2
+	CodeOrderNotOpen     CodeType = 3
+)
+
+//----------------------------------------
+// errors returned by the chain
+
+// ErrFromABCI rebuilds the sdk Error from the codespace, code and log of an ABCI response.
+// The message is extracted from the log if it's a JSON encoded error or a list of message logs.
+func ErrFromABCI(codespace string, code uint32, log string) Error {
+	hrErr := parseABCIErrLog(log)
+	if codespace == "" {
+		codespace = string(hrErr.Codespace)
+	}
+	if codespace == "" {
+		codespace = string(CodespaceRoot)
+	}
+
+	if hrErr.Message == "" {
+		return newError(CodespaceType(codespace), CodeType(code), "")
+	}
+	return newError(CodespaceType(codespace), CodeType(code), "%s", hrErr.Message)
+}
+
+func parseABCIErrLog(log string) (hrErr humanReadableError) {
+	if err := json.Unmarshal([]byte(log), &hrErr); err == nil && hrErr.Message != "" {
+		return hrErr
+	}
+
+	// the log of a failed DeliverTx is a list of message logs
+	if msgLogs, err := ParseABCILogs(log); err == nil {
+		for _, msgLog := range msgLogs {
+			if !msgLog.Success {
+				return parseABCIErrLog(msgLog.Log)
+			}
+		}
+	}
+
+	return humanReadableError{Message: log}
+}
+
+// Is reports whether the target is an sdk Error with the same codespace and code, so that
+// errors.Is works with the error constructors, e.g.
+//
+//	errors.Is(err, types.ErrInsufficientFunds(""))
+func (err *sdkError) Is(target error) bool {
+	t, ok := target.(Error)
+	if !ok {
+		return false
+	}
+	return err.codespace == t.Codespace() && err.code == t.Code()
+}
+
+// TxError is returned when a tx is rejected by the chain. It carries the response of the tx and
+// wraps the sdk Error rebuilt from it, so both of them can be extracted with errors.As.
+type TxError struct {
+	Response TxResponse
+	Err      Error
+}
+
+// NewTxError creates a TxError from the response of a rejected tx
+func NewTxError(res TxResponse) *TxError {
+	return &TxError{
+		Response: res,
+		Err:      ErrFromABCI(res.Codespace, res.Code, res.RawLog),
+	}
+}
+
+// Error implements the error interface.
+func (e *TxError) Error() string {
+	return fmt.Sprintf("tx %s failed at height %d: codespace %s, code %d: %s",
+		e.Response.TxHash, e.Response.Height, e.Err.Codespace(), e.Err.Code(), e.Err.Data())
+}
+
+// Unwrap returns the sdk Error of the tx
+func (e *TxError) Unwrap() error {
+	return e.Err
+}
+
+// Height returns the height of the block where the tx was rejected. It's 0 if the tx was
+// rejected before entering a block.
+func (e *TxError) Height() int64 {
+	return e.Response.Height
+}
+
+// TxHash returns the hash of the rejected tx
+func (e *TxError) TxHash() string {
+	return e.Response.TxHash
+}
+
+//----------------------------------------
+// errors of the checks before broadcasting
+
+// InsufficientFundsError is returned when the available funds of an account, less the funds
+// reserved for its txs in flight, don't cover a tx. The tx isn't broadcast. It wraps
+// ErrInsufficientCoins, so IsErrInsufficientFunds reports it like the rejections of the chain.
+type InsufficientFundsError struct {
+	Address   string
+	Required  DecCoins
+	Available DecCoins
+	Reserved  DecCoins
+}
+
+// Shortfall returns the funds missing to cover the tx
+func (e *InsufficientFundsError) Shortfall() DecCoins {
+	shortfall, _ := e.Available.SafeSub(e.Reserved)
+	shortfall, _ = shortfall.SafeSub(e.Required)
+	var missing DecCoins
+	for _, coin := range shortfall {
+		if coin.IsNegative() {
+			missing = append(missing, DecCoin{coin.Denom, coin.Amount.Neg()})
+		}
+	}
+	return missing
+}
+
+// Error implements the error interface.
+func (e *InsufficientFundsError) Error() string {
+	return fmt.Sprintf("insufficient funds of %s: %s required, %s available, %s reserved, %s missing",
+		e.Address, fundsString(e.Required), fundsString(e.Available), fundsString(e.Reserved), fundsString(e.Shortfall()))
+}
+
+func fundsString(coins DecCoins) string {
+	if coins.Empty() {
+		return "nothing"
+	}
+	return coins.String()
+}
+
+// Unwrap returns the sdk Error of the insufficient funds
+func (e *InsufficientFundsError) Unwrap() error {
+	return ErrInsufficientCoins(fmt.Sprintf("insufficient funds of %s: %s missing", e.Address, e.Shortfall()))
+}
+
+//----------------------------------------
+// error checks
+
+// IsErrCode returns true if err is or wraps an sdk Error with the given codespace and code
+func IsErrCode(err error, codespace CodespaceType, code CodeType) bool {
+	var sdkErr Error
+	if !errors.As(err, &sdkErr) {
+		return false
+	}
+	return sdkErr.Codespace() == codespace && sdkErr.Code() == code
+}
+
+// IsErrInsufficientFunds returns true if err reports that the account can't afford the tx
+func IsErrInsufficientFunds(err error) bool {
+	return IsErrCode(err, CodespaceRoot, CodeInsufficientFunds) || IsErrCode(err, CodespaceRoot, CodeInsufficientCoins)
+}
+
+// IsErrInvalidSequence returns true if err reports a wrong sequence of the signer
+func IsErrInvalidSequence(err error) bool {
+	return IsErrCode(err, CodespaceRoot, CodeInvalidSequence)
+}
+
+// IsErrUnauthorized returns true if err reports a failed signature verification
+func IsErrUnauthorized(err error) bool {
+	return IsErrCode(err, CodespaceRoot, CodeUnauthorized)
+}
+
+// IsErrUnknownRequest returns true if err reports an unknown request or query path
+func IsErrUnknownRequest(err error) bool {
+	return IsErrCode(err, CodespaceRoot, CodeUnknownRequest)
+}
+
+// IsErrOrderNotFound returns true if err reports an order which doesn't exist
+func IsErrOrderNotFound(err error) bool {
+	return IsErrCode(err, CodespaceOrder, CodeOrderNotExist)
+}
@@ -0,0 +1,68 @@
+package types
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+)
+
+func TestErrFromABCI(t *testing.T) {
+	err := ErrFromABCI("sdk", 5, `{"codespace":"sdk","code":5,"message":"insufficient account funds; 1okt < 10okt"}`)
+	if err.Codespace() != CodespaceRoot || err.Code() != CodeInsufficientFunds {
+		t.Fatalf("unexpected codespace or code: %s", err)
+	}
+	if fmt.Sprint(err.Data()) != "insufficient account funds; 1okt < 10okt" {
+		t.Errorf("unexpected message: %s", err.Data())
+	}
+
+	// log of a failed DeliverTx
+	msgLogs := `[{"msg_index":0,"success":false,"log":"{\"codespace\":\"order\",\"code\":2,\"message\":\"order(ID0000000001-1) does not exist\"}"}]`
+	err = ErrFromABCI("", 2, msgLogs)
+	if err.Codespace() != CodespaceOrder {
+		t.Errorf("expected codespace %s, got %s", CodespaceOrder, err.Codespace())
+	}
+	if !IsErrOrderNotFound(err) {
+		t.Errorf("expected order not found: %s", err)
+	}
+	// the message doesn't matter, only the codespace and the code
+	if IsErrOrderNotFound(ErrUnknownRequest("order(ID0000000001-1) not found")) ||
+		IsErrOrderNotFound(NewError(CodespaceOrder, CodeOrderNotOpen, "order(ID0000000001-1) does not exist")) {
+		t.Error("unexpected order not found of another error")
+	}
+
+	// plain text log
+	err = ErrFromABCI("", 4, "signature verification failed")
+	if !IsErrUnauthorized(err) || fmt.Sprint(err.Data()) != "signature verification failed" {
+		t.Errorf("unexpected error: %s", err)
+	}
+}
+
+func TestTxError(t *testing.T) {
+	res := TxResponse{
+		Height:    1024,
+		TxHash:    "12CF714D13D9B86EDCCBE41BF55845BF96613977AFF8E503C5A5349A50841F9A",
+		Code:      uint32(CodeInvalidSequence),
+		Codespace: string(CodespaceRoot),
+		RawLog:    `{"codespace":"sdk","code":3,"message":"invalid sequence; got 5, expected 6"}`,
+	}
+	err := fmt.Errorf("send failed: %w", NewTxError(res))
+
+	var txErr *TxError
+	if !errors.As(err, &txErr) {
+		t.Fatal("TxError expected")
+	}
+	if txErr.Height() != res.Height || txErr.TxHash() != res.TxHash {
+		t.Errorf("unexpected height or hash: %s", txErr)
+	}
+
+	var sdkErr Error
+	if !errors.As(err, &sdkErr) || sdkErr.Code() != CodeInvalidSequence {
+		t.Fatalf("sdk Error expected: %v", err)
+	}
+	if !IsErrInvalidSequence(err) || IsErrInsufficientFunds(err) {
+		t.Errorf("unexpected classification: %s", err)
+	}
+	if !errors.Is(err, ErrInvalidSequence("")) {
+		t.Errorf("errors.Is should match the codespace and code")
+	}
+}
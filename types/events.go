@@ -141,6 +141,23 @@ var (
 	AttributeKeyFee    = "fee"
 )
 
+// attribute keys of the events of the order module
+const (
+	// AttributeKeyOrderID is the key of the id of every order placed or canceled by a msg
+	AttributeKeyOrderID = "orderId"
+	// AttributeKeyOrders is the key of the results of the order items of a MsgNewOrders, which are
+	// a JSON list of OrderItemResult in the order of the items
+	AttributeKeyOrders = "orders"
+)
+
+// OrderItemResult is the result of an order item of a MsgNewOrders. The order is placed if the
+// code is 0, otherwise it's rejected for the message while the other items may be placed.
+type OrderItemResult struct {
+	Code    uint32 `json:"code"`
+	Message string `json:"msg"`
+	OrderID string `json:"orderid"`
+}
+
 type (
 	// StringAttribute defines en Event object wrapper where all the attributes
 	// contain key/value pairs that are strings instead of raw bytes.
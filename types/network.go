@@ -0,0 +1,290 @@
+package types
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/tendermint/go-amino"
+	"github.com/tendermint/tendermint/crypto"
+	cryptoAmino "github.com/tendermint/tendermint/crypto/encoding/amino"
+	"github.com/tendermint/tendermint/libs/bech32"
+)
+
+const (
+	// DefaultChainID is the chain-id of the default network
+	DefaultChainID = "okchain"
+	// DefaultBaseDenom is the denom of the native token of the default network
+	DefaultBaseDenom = "okt"
+)
+
+// Bech32Prefixes are the Bech32 prefixes of the addresses and the public keys of a chain
+type Bech32Prefixes struct {
+	AccountAddr   string `json:"account_addr"`
+	AccountPub    string `json:"account_pub"`
+	ValidatorAddr string `json:"validator_addr"`
+	ValidatorPub  string `json:"validator_pub"`
+	ConsensusAddr string `json:"consensus_addr"`
+	ConsensusPub  string `json:"consensus_pub"`
+}
+
+// NewBech32Prefixes returns the prefixes derived from the main prefix of a chain like the ones of
+// OKChain, e.g. okchain, okchainpub, okchainvaloper and okchainvalcons
+func NewBech32Prefixes(mainPrefix string) Bech32Prefixes {
+	return Bech32Prefixes{
+		AccountAddr:   mainPrefix,
+		AccountPub:    mainPrefix + PrefixPublic,
+		ValidatorAddr: mainPrefix + PrefixValidator + PrefixOperator,
+		ValidatorPub:  mainPrefix + PrefixValidator + PrefixOperator + PrefixPublic,
+		ConsensusAddr: mainPrefix + PrefixValidator + PrefixConsensus,
+		ConsensusPub:  mainPrefix + PrefixValidator + PrefixConsensus + PrefixPublic,
+	}
+}
+
+// mapping maps the prefixes to the ones of the same kinds of other prefixes
+func (p Bech32Prefixes) mapping(to Bech32Prefixes) map[string]string {
+	return map[string]string{
+		p.AccountAddr:   to.AccountAddr,
+		p.AccountPub:    to.AccountPub,
+		p.ValidatorAddr: to.ValidatorAddr,
+		p.ValidatorPub:  to.ValidatorPub,
+		p.ConsensusAddr: to.ConsensusAddr,
+		p.ConsensusPub:  to.ConsensusPub,
+	}
+}
+
+// Network is the profile of a chain used by a client: its chain-id, Bech32 prefixes, native token,
+// default fee and codec. A process can talk to several chains, like the mainnet and a testnet
+// with other prefixes, by a client of each network. The global Config is the default network.
+//
+// The addresses held by the types, like AccAddress, are always encoded by the global Config. The
+// clients convert the address fields of the JSON they sign and receive between the prefixes of
+// their network and the global ones, and the addresses in the prefixes of a network are parsed and
+// formatted by its methods.
+type Network struct {
+	ChainID        string         `json:"chain_id"`
+	Bech32Prefixes Bech32Prefixes `json:"bech32_prefixes"`
+	// BaseDenom is the denom of the native token, which the fees are paid in
+	BaseDenom string `json:"base_denom"`
+	// Gas and Fees are the fee of the txs built by the clients of the network
+	Gas  uint64 `json:"gas"`
+	Fees Coins  `json:"fees"`
+	// Codec decodes the results of the queries. It's the default codec of the sdk if it's nil.
+	Codec *amino.Codec `json:"-"`
+}
+
+// DefaultNetwork returns the network of the global Config, whose chain-id is okchain
+func DefaultNetwork() Network {
+	config := GetConfig()
+	return Network{
+		ChainID: DefaultChainID,
+		Bech32Prefixes: Bech32Prefixes{
+			AccountAddr:   config.GetBech32AccountAddrPrefix(),
+			AccountPub:    config.GetBech32AccountPubPrefix(),
+			ValidatorAddr: config.GetBech32ValidatorAddrPrefix(),
+			ValidatorPub:  config.GetBech32ValidatorPubPrefix(),
+			ConsensusAddr: config.GetBech32ConsensusAddrPrefix(),
+			ConsensusPub:  config.GetBech32ConsensusPubPrefix(),
+		},
+		BaseDenom: DefaultBaseDenom,
+	}
+}
+
+// Validate checks that the chain-id and the prefixes are given and that the prefixes are distinct
+func (n Network) Validate() error {
+	if strings.TrimSpace(n.ChainID) == "" {
+		return errors.New("empty chain-id of the network")
+	}
+	prefixes := n.Bech32Prefixes.mapping(n.Bech32Prefixes)
+	if len(prefixes) != 6 {
+		return fmt.Errorf("the Bech32 prefixes of the network %s aren't distinct", n.ChainID)
+	}
+	for prefix := range prefixes {
+		if prefix == "" || prefix != strings.ToLower(prefix) {
+			return fmt.Errorf("invalid Bech32 prefix %q of the network %s", prefix, n.ChainID)
+		}
+	}
+	if err := validateDenom(n.BaseDenom); n.BaseDenom != "" && err != nil {
+		return fmt.Errorf("invalid base denom %q of the network %s", n.BaseDenom, n.ChainID)
+	}
+	if !n.Fees.IsValid() {
+		return fmt.Errorf("invalid fees %s of the network %s", n.Fees, n.ChainID)
+	}
+	return nil
+}
+
+// IsDefault returns whether the addresses of the network are encoded as the ones of the global
+// Config, so that no conversion is needed
+func (n Network) IsDefault() bool {
+	return n.Bech32Prefixes == DefaultNetwork().Bech32Prefixes
+}
+
+// ParseAccAddress parses an account address encoded by the prefix of the network
+func (n Network) ParseAccAddress(address string) (AccAddress, error) {
+	bz, err := addressFromBech32(address, n.Bech32Prefixes.AccountAddr)
+	return AccAddress(bz), err
+}
+
+// FormatAccAddress encodes the account address by the prefix of the network
+func (n Network) FormatAccAddress(addr AccAddress) string {
+	return formatAddress(addr, n.Bech32Prefixes.AccountAddr)
+}
+
+// ParseValAddress parses a validator operator address encoded by the prefix of the network
+func (n Network) ParseValAddress(address string) (ValAddress, error) {
+	bz, err := addressFromBech32(address, n.Bech32Prefixes.ValidatorAddr)
+	return ValAddress(bz), err
+}
+
+// FormatValAddress encodes the validator operator address by the prefix of the network
+func (n Network) FormatValAddress(addr ValAddress) string {
+	return formatAddress(addr, n.Bech32Prefixes.ValidatorAddr)
+}
+
+// ParseConsAddress parses a consensus address encoded by the prefix of the network
+func (n Network) ParseConsAddress(address string) (ConsAddress, error) {
+	bz, err := addressFromBech32(address, n.Bech32Prefixes.ConsensusAddr)
+	return ConsAddress(bz), err
+}
+
+// FormatConsAddress encodes the consensus address by the prefix of the network
+func (n Network) FormatConsAddress(addr ConsAddress) string {
+	return formatAddress(addr, n.Bech32Prefixes.ConsensusAddr)
+}
+
+// ParseAccPubKey parses an account public key encoded by the prefix of the network
+func (n Network) ParseAccPubKey(pubkey string) (crypto.PubKey, error) {
+	bz, err := GetFromBech32(pubkey, n.Bech32Prefixes.AccountPub)
+	if err != nil {
+		return nil, err
+	}
+	return cryptoAmino.PubKeyFromBytes(bz)
+}
+
+// FormatAccPubKey encodes the account public key by the prefix of the network
+func (n Network) FormatAccPubKey(pub crypto.PubKey) (string, error) {
+	return bech32.ConvertAndEncode(n.Bech32Prefixes.AccountPub, pub.Bytes())
+}
+
+// bech32JSONKeys are the JSON keys of the addresses and the public keys of the msgs and the query
+// results. Only the Bech32 strings under them are converted, so that free text which happens to
+// be Bech32, like a memo or a description, is kept as it is.
+var bech32JSONKeys = map[string]bool{
+	"address":      true,
+	"from":         true,
+	"from_address": true,
+	"owner":        true,
+	"proposer":     true,
+	"pub_key":      true,
+	"sender":       true,
+	"signer":       true,
+	"to":           true,
+	"to_address":   true,
+}
+
+// ToDefaultJSON converts the Bech32 addresses and public keys of the JSON from the prefixes of the
+// network to the ones of the global Config, e.g. to decode a query result of the network
+func (n Network) ToDefaultJSON(bz []byte) ([]byte, error) {
+	return convertBech32JSON(bz, n.Bech32Prefixes.mapping(DefaultNetwork().Bech32Prefixes))
+}
+
+// FromDefaultJSON converts the Bech32 addresses and public keys of the JSON from the prefixes of
+// the global Config to the ones of the network, e.g. the sign bytes of a msg
+func (n Network) FromDefaultJSON(bz []byte) ([]byte, error) {
+	return convertBech32JSON(bz, DefaultNetwork().Bech32Prefixes.mapping(n.Bech32Prefixes))
+}
+
+func addressFromBech32(address, prefix string) ([]byte, error) {
+	if len(strings.TrimSpace(address)) == 0 {
+		return []byte{}, nil
+	}
+
+	bz, err := GetFromBech32(address, prefix)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(bz) != AddrLen {
+		return nil, errors.New("Incorrect address length")
+	}
+
+	return bz, nil
+}
+
+func formatAddress(bz []byte, prefix string) string {
+	if len(bz) == 0 {
+		return ""
+	}
+
+	bech32Addr, err := bech32.ConvertAndEncode(prefix, bz)
+	if err != nil {
+		panic(err)
+	}
+
+	return bech32Addr
+}
+
+// convertBech32JSON re-encodes the strings under the address keys of the JSON which are Bech32
+// strings of the prefixes of the mapping by the mapped prefixes. The JSON is returned with sorted
+// keys.
+func convertBech32JSON(bz []byte, mapping map[string]string) ([]byte, error) {
+	identity := true
+	for from, to := range mapping {
+		if from != to {
+			identity = false
+		}
+	}
+	if identity {
+		return bz, nil
+	}
+
+	decoder := json.NewDecoder(bytes.NewReader(bz))
+	decoder.UseNumber()
+	var v interface{}
+	if err := decoder.Decode(&v); err != nil {
+		return nil, err
+	}
+	v, err := convertBech32Value(v, mapping, false)
+	if err != nil {
+		return nil, err
+	}
+	return json.Marshal(v)
+}
+
+// convertBech32Value converts the value, whose strings are addresses if it's under an address key
+func convertBech32Value(v interface{}, mapping map[string]string, isAddress bool) (interface{}, error) {
+	switch value := v.(type) {
+	case string:
+		if !isAddress {
+			return value, nil
+		}
+		hrp, bz, err := bech32.DecodeAndConvert(value)
+		if err != nil {
+			return value, nil
+		}
+		to, ok := mapping[hrp]
+		if !ok || to == hrp {
+			return value, nil
+		}
+		return bech32.ConvertAndEncode(to, bz)
+	case []interface{}:
+		for i, item := range value {
+			converted, err := convertBech32Value(item, mapping, isAddress)
+			if err != nil {
+				return nil, err
+			}
+			value[i] = converted
+		}
+	case map[string]interface{}:
+		for key, item := range value {
+			converted, err := convertBech32Value(item, mapping, bech32JSONKeys[key])
+			if err != nil {
+				return nil, err
+			}
+			value[key] = converted
+		}
+	}
+	return v, nil
+}
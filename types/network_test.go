@@ -0,0 +1,62 @@
+package types
+
+import (
+	"testing"
+)
+
+func TestNetwork(t *testing.T) {
+	if err := DefaultNetwork().Validate(); err != nil {
+		t.Fatal(err)
+	}
+	if !DefaultNetwork().IsDefault() {
+		t.Fatal("the default network needs conversions")
+	}
+	network := Network{ChainID: "okfork-1", Bech32Prefixes: NewBech32Prefixes("okfork")}
+	if err := network.Validate(); err != nil {
+		t.Fatal(err)
+	}
+	if network.IsDefault() || network.Bech32Prefixes.ValidatorAddr != "okforkvaloper" {
+		t.Fatalf("unexpected prefixes of the network: %+v", network.Bech32Prefixes)
+	}
+	invalid := network
+	invalid.Bech32Prefixes.AccountPub = invalid.Bech32Prefixes.AccountAddr
+	if err := invalid.Validate(); err == nil {
+		t.Fatal("a network with the same prefix of two kinds is valid")
+	}
+	invalid = network
+	invalid.ChainID = " "
+	if err := invalid.Validate(); err == nil {
+		t.Fatal("a network without a chain-id is valid")
+	}
+
+	addr, err := AccAddressFromBech32("okchain1g7c3nvac7mjgn2m9mqllgat8wwd3aptdqket5k")
+	if err != nil {
+		t.Fatal(err)
+	}
+	forkAddr := network.FormatAccAddress(addr)
+	if parsed, err := network.ParseAccAddress(forkAddr); err != nil || !parsed.Equals(addr) {
+		t.Fatalf("failed to parse %s: %v", forkAddr, err)
+	}
+	if _, err := network.ParseAccAddress(addr.String()); err == nil {
+		t.Fatal("an address of the default network is parsed by the fork")
+	}
+	valAddr := network.FormatValAddress(addr.ToValAddress())
+	if parsed, err := network.ParseValAddress(valAddr); err != nil || !parsed.Equals(addr.ToValAddress()) {
+		t.Fatalf("failed to parse %s: %v", valAddr, err)
+	}
+
+	// the addresses of the JSON are converted and the other strings are kept, even if they're Bech32
+	defaultJSON := `{"amount":"1.5","description":"` + addr.String() + `","from":"` + addr.String() + `","memo":"okchain1 isn't an address","to":["` + addr.ToValAddress().String() + `"]}`
+	forkJSON := `{"amount":"1.5","description":"` + addr.String() + `","from":"` + forkAddr + `","memo":"okchain1 isn't an address","to":["` + valAddr + `"]}`
+	converted, err := network.FromDefaultJSON([]byte(defaultJSON))
+	if err != nil || string(converted) != forkJSON {
+		t.Fatalf("unexpected JSON converted to the network: %s, %v", converted, err)
+	}
+	converted, err = network.ToDefaultJSON([]byte(forkJSON))
+	if err != nil || string(converted) != defaultJSON {
+		t.Fatalf("unexpected JSON converted to the default network: %s, %v", converted, err)
+	}
+	if converted, _ := DefaultNetwork().FromDefaultJSON([]byte(" {}")); string(converted) != " {}" {
+		t.Fatal("the JSON of the default network is converted")
+	}
+}
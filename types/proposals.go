@@ -79,6 +79,27 @@ func (pt *ProposalKind) UnmarshalJSON(data []byte) error {
 	return nil
 }
 
+// Marshals to JSON using the name of the proposal type
+func (pt ProposalKind) MarshalJSON() ([]byte, error) {
+	return json.Marshal(pt.String())
+}
+
+// String returns the name of the proposal type
+func (pt ProposalKind) String() string {
+	switch pt {
+	case ProposalTypeText:
+		return "Text"
+	case ProposalTypeParameterChange:
+		return "ParameterChange"
+	case ProposalTypeAppUpgrade:
+		return "AppUpgrade"
+	case ProposalTypeDexList:
+		return "DexList"
+	default:
+		return ""
+	}
+}
+
 // String to proposalType byte. Returns 0xff if invalid.
 func ProposalTypeFromString(str string) (ProposalKind, error) {
 	switch str {
@@ -113,6 +134,27 @@ func (status *ProposalStatus) UnmarshalJSON(data []byte) error {
 	return nil
 }
 
+// Marshals to JSON using the name of the proposal status
+func (status ProposalStatus) MarshalJSON() ([]byte, error) {
+	return json.Marshal(status.String())
+}
+
+// String returns the name of the proposal status
+func (status ProposalStatus) String() string {
+	switch status {
+	case StatusDepositPeriod:
+		return "DepositPeriod"
+	case StatusVotingPeriod:
+		return "VotingPeriod"
+	case StatusPassed:
+		return "Passed"
+	case StatusRejected:
+		return "Rejected"
+	default:
+		return ""
+	}
+}
+
 // ProposalStatusToString turns a string into a ProposalStatus
 func ProposalStatusFromString(str string) (ProposalStatus, error) {
 	switch str {
@@ -221,7 +221,7 @@ func (r TxResponse) String() string {
 	}
 
 	if r.Logs != nil {
-		sb.WriteString(fmt.Sprintf("  Logs: %s\n", r.Logs))
+		sb.WriteString(fmt.Sprintf("  Logs: %v\n", r.Logs))
 	}
 
 	if r.Info != "" {
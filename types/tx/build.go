@@ -3,6 +3,7 @@ package tx
 import (
 	"fmt"
 	"github.com/okex/okchain-go-sdk/crypto/encoding/codec"
+	"github.com/okex/okchain-go-sdk/crypto/keys"
 	"github.com/okex/okchain-go-sdk/types"
 	"github.com/okex/okchain-go-sdk/types/msg"
 	"github.com/okex/okchain-go-sdk/utils"
@@ -19,17 +20,17 @@ func init() {
 	RegisterMsgCdc(MsgCdc)
 }
 
-func buildTx(fromName, passphrase, memo string, msgs []types.Msg, accNumber, seqNumber uint64) (StdTx, error) {
+func buildTx(network types.Network, fromName, passphrase, memo string, msgs []types.Msg, accNumber, seqNumber uint64) (StdTx, error) {
 	signMsg := StdSignMsg{
-		ChainID:       "okchain",
+		ChainID:       network.ChainID,
 		AccountNumber: accNumber,
 		Sequence:      seqNumber,
 		Memo:          memo,
 		Msgs:          msgs,
-		Fee:           NewStdFee(0, nil),
+		Fee:           NewStdFee(network.Gas, network.Fees),
 	}
 
-	sig, err := makeSignature(utils.Kb, fromName, passphrase, signMsg)
+	sig, err := makeSignature(utils.Kb, fromName, passphrase, network, signMsg)
 	if err != nil {
 		return StdTx{}, err
 	}
@@ -37,8 +38,55 @@ func buildTx(fromName, passphrase, memo string, msgs []types.Msg, accNumber, seq
 	return NewStdTx(signMsg.Msgs, signMsg.Fee, []StdSignature{sig}, signMsg.Memo), nil
 }
 
+// BuildUnsignedStdTx builds a StdTx of the msgs without any signature, e.g. to be signed offline
+func BuildUnsignedStdTx(memo string, msgs []types.Msg) StdTx {
+	return NewStdTx(msgs, NewStdFee(0, nil), nil, memo)
+}
+
+// SignStdTx signs the StdTx with the named key of utils.Kb and returns it with the signature appended
+func SignStdTx(fromName, passphrase string, stdTx StdTx, accNumber, seqNumber uint64) (StdTx, error) {
+	return SignStdTxWithKeybase(utils.Kb, fromName, passphrase, stdTx, accNumber, seqNumber)
+}
+
+// SignStdTxWithKeybase signs the StdTx with the named key of the given keybase
+func SignStdTxWithKeybase(kb keys.Keybase, fromName, passphrase string, stdTx StdTx, accNumber, seqNumber uint64) (StdTx, error) {
+	return SignStdTxWithNetwork(types.DefaultNetwork(), kb, fromName, passphrase, stdTx, accNumber, seqNumber)
+}
+
+// SignStdTxWithNetwork signs the StdTx for the network with the named key of the given keybase.
+// The fee of the tx is signed as it is. Only a tx without any fee, e.g. built by
+// BuildUnsignedStdTx, gets the fee of the network.
+func SignStdTxWithNetwork(network types.Network, kb keys.Keybase, fromName, passphrase string, stdTx StdTx, accNumber, seqNumber uint64) (StdTx, error) {
+	fee := stdTx.Fee
+	if fee.Gas == 0 && len(fee.Amount) == 0 {
+		fee = NewStdFee(network.Gas, network.Fees)
+	}
+	signMsg := StdSignMsg{
+		ChainID:       network.ChainID,
+		AccountNumber: accNumber,
+		Sequence:      seqNumber,
+		Memo:          stdTx.Memo,
+		Msgs:          stdTx.Msgs,
+		Fee:           fee,
+	}
+
+	sig, err := makeSignature(kb, fromName, passphrase, network, signMsg)
+	if err != nil {
+		return StdTx{}, err
+	}
+
+	sigs := append(append([]StdSignature(nil), stdTx.Signatures...), sig)
+	return NewStdTx(signMsg.Msgs, signMsg.Fee, sigs, signMsg.Memo), nil
+}
+
 func BuildAndSignAndEncodeStdTx(fromName, passphrase, memo string, msgs []types.Msg, accNumber, seqNumber uint64) ([]byte, error) {
-	stdTx, err := buildTx(fromName, passphrase, memo, msgs, accNumber, seqNumber)
+	return BuildAndSignAndEncodeStdTxWithNetwork(types.DefaultNetwork(), fromName, passphrase, memo, msgs, accNumber, seqNumber)
+}
+
+// BuildAndSignAndEncodeStdTxWithNetwork builds the tx of the msgs for the network, signs it with
+// the named key of utils.Kb and encodes it by amino
+func BuildAndSignAndEncodeStdTxWithNetwork(network types.Network, fromName, passphrase, memo string, msgs []types.Msg, accNumber, seqNumber uint64) ([]byte, error) {
+	stdTx, err := buildTx(network, fromName, passphrase, memo, msgs, accNumber, seqNumber)
 	if err != nil {
 		return nil, fmt.Errorf("build stdTx error: %s", err)
 	}
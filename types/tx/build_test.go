@@ -0,0 +1,41 @@
+package tx_test
+
+import (
+	"testing"
+
+	"github.com/okex/okchain-go-sdk/crypto/keys"
+	"github.com/okex/okchain-go-sdk/types"
+	"github.com/okex/okchain-go-sdk/types/msg"
+	"github.com/okex/okchain-go-sdk/types/tx"
+)
+
+func TestSignStdTxFee(t *testing.T) {
+	kb := keys.NewInMemory()
+	info, err := kb.CreateAccount("alice", mnemonic, "", passWd, 0, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	to, _ := types.AccAddressFromBech32(addr1)
+	msgs := []types.Msg{msg.NewMsgTokenSend(info.GetAddress(), to, types.Coins{types.NewCoin("okt", types.NewInt(1))})}
+	network := types.DefaultNetwork()
+	network.Gas, network.Fees = 100000, types.Coins{types.NewCoin("okt", types.NewInt(2))}
+
+	// the fee of the tx is signed as it is
+	fee := tx.NewStdFee(200000, types.Coins{types.NewCoin("okt", types.NewInt(3))})
+	signed, err := tx.SignStdTxWithNetwork(network, kb, "alice", passWd, tx.NewStdTx(msgs, fee, nil, ""), 0, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if signed.Fee.Gas != fee.Gas || !signed.Fee.Amount.IsEqual(fee.Amount) {
+		t.Fatalf("unexpected fee signed: %+v", signed.Fee)
+	}
+
+	// a tx without any fee gets the one of the network
+	signed, err = tx.SignStdTxWithNetwork(network, kb, "alice", passWd, tx.BuildUnsignedStdTx("", msgs), 0, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if signed.Fee.Gas != network.Gas || !signed.Fee.Amount.IsEqual(network.Fees) {
+		t.Fatalf("unexpected fee of the network signed: %+v", signed.Fee)
+	}
+}
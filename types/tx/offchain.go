@@ -0,0 +1,112 @@
+package tx
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/okex/okchain-go-sdk/crypto/keys"
+	"github.com/okex/okchain-go-sdk/types"
+	"github.com/tendermint/tendermint/crypto"
+)
+
+// MsgSignDataType is the type of the only msg of an off-chain sign doc. It isn't registered on the
+// chain, so the doc can't be replayed as a tx.
+const MsgSignDataType = "okchain/MsgSignData"
+
+// OffChainDomain binds an off-chain signature to a chain and to the service asking for it, like the
+// host of a website, so that it can't be replayed to another service
+type OffChainDomain struct {
+	ChainID string `json:"chain_id"`
+	Domain  string `json:"domain"`
+}
+
+// ValidateBasic checks that the chain-id and the domain are given
+func (d OffChainDomain) ValidateBasic() error {
+	if d.ChainID == "" || d.Domain == "" {
+		return errors.New("the chain-id and the domain of an off-chain signature are required")
+	}
+	return nil
+}
+
+// MsgSignData is the msg of an off-chain sign doc, holding the signed data
+type MsgSignData struct {
+	Signer types.AccAddress `json:"signer"`
+	Domain string           `json:"domain"`
+	Data   []byte           `json:"data"`
+}
+
+// OffChainSignBytes returns the bytes signed for the data. They're the sorted JSON of a sign doc
+// shaped as the one of a tx with zero account number, sequence and fee, whose only msg is a
+// MsgSignData. So a Ledger device displays and signs it as a tx.
+func OffChainSignBytes(domain OffChainDomain, signer types.AccAddress, data []byte) []byte {
+	msgBytes, err := json.Marshal(struct {
+		Type  string      `json:"type"`
+		Value MsgSignData `json:"value"`
+	}{MsgSignDataType, MsgSignData{Signer: signer, Domain: domain.Domain, Data: data}})
+	if err != nil {
+		panic(err)
+	}
+	bz, err := json.Marshal(struct {
+		AccountNumber string            `json:"account_number"`
+		ChainID       string            `json:"chain_id"`
+		Fee           json.RawMessage   `json:"fee"`
+		Memo          string            `json:"memo"`
+		Msgs          []json.RawMessage `json:"msgs"`
+		Sequence      string            `json:"sequence"`
+	}{
+		AccountNumber: "0",
+		ChainID:       domain.ChainID,
+		Fee:           NewStdFee(0, nil).Bytes(),
+		Msgs:          []json.RawMessage{msgBytes},
+		Sequence:      "0",
+	})
+	if err != nil {
+		panic(err)
+	}
+	return types.MustSortJSON(bz)
+}
+
+// SignArbitrary signs the data off-chain with the named key of the keybase, e.g. a login challenge.
+// Ledger keys sign it on the device.
+func SignArbitrary(kb keys.Keybase, name, passphrase string, domain OffChainDomain, data []byte) (StdSignature, error) {
+	if err := domain.ValidateBasic(); err != nil {
+		return StdSignature{}, err
+	}
+	info, err := kb.Get(name)
+	if err != nil {
+		return StdSignature{}, err
+	}
+	sig, pubKey, err := kb.Sign(name, passphrase, OffChainSignBytes(domain, info.GetAddress(), data))
+	if err != nil {
+		return StdSignature{}, err
+	}
+	return StdSignature{PubKey: pubKey, Signature: sig}, nil
+}
+
+// VerifyArbitrary verifies the off-chain signature of the data by the public key
+func VerifyArbitrary(pubKey crypto.PubKey, domain OffChainDomain, data, sig []byte) error {
+	if err := domain.ValidateBasic(); err != nil {
+		return err
+	}
+	if pubKey == nil {
+		return errors.New("no public key to verify the signature")
+	}
+	if !pubKey.VerifyBytes(OffChainSignBytes(domain, types.AccAddress(pubKey.Address()), data), sig) {
+		return errors.New("invalid off-chain signature")
+	}
+	return nil
+}
+
+// VerifyArbitraryByAddress verifies the off-chain signature of the data by the address, whose
+// public key is taken from the signature
+func VerifyArbitraryByAddress(addr types.AccAddress, domain OffChainDomain, data []byte, sig StdSignature) error {
+	if sig.PubKey == nil {
+		return errors.New("no public key in the signature")
+	}
+	if signer := types.AccAddress(sig.PubKey.Address()); !bytes.Equal(signer, addr) {
+		return fmt.Errorf("the signature is made by %s instead of %s", signer, addr)
+	}
+	return VerifyArbitrary(sig.PubKey, domain, data, sig.Signature)
+}
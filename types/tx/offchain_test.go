@@ -0,0 +1,66 @@
+package tx_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/okex/okchain-go-sdk/crypto/keys"
+	"github.com/okex/okchain-go-sdk/types"
+	"github.com/okex/okchain-go-sdk/types/tx"
+)
+
+const (
+	passWd   = "12345678"
+	mnemonic = "total lottery arena when pudding best candy until army spoil drill pool"
+	addr     = "okchain1g7c3nvac7mjgn2m9mqllgat8wwd3aptdqket5k"
+	addr1    = "okchain1hw4r48aww06ldrfeuq2v438ujnl6alszzzqpph"
+)
+
+func TestSignArbitrary(t *testing.T) {
+	kb := keys.NewInMemory()
+	if _, err := kb.CreateAccount("alice", mnemonic, "", passWd, 0, 0); err != nil {
+		t.Fatal(err)
+	}
+	domain := tx.OffChainDomain{ChainID: "okchain", Domain: "example.com"}
+	challenge := []byte("login challenge 42")
+
+	sig, err := tx.SignArbitrary(kb, "alice", passWd, domain, challenge)
+	if err != nil {
+		t.Fatal(err)
+	}
+	accAddr, _ := types.AccAddressFromBech32(addr)
+	if err := tx.VerifyArbitraryByAddress(accAddr, domain, challenge, sig); err != nil {
+		t.Fatal(err)
+	}
+	if err := tx.VerifyArbitrary(sig.PubKey, domain, challenge, sig.Signature); err != nil {
+		t.Fatal(err)
+	}
+
+	// the signature is bound to the data, the domain, the chain and the signer
+	if err := tx.VerifyArbitraryByAddress(accAddr, domain, []byte("another challenge"), sig); err == nil {
+		t.Fatal("the signature is valid for other data")
+	}
+	if err := tx.VerifyArbitraryByAddress(accAddr, tx.OffChainDomain{ChainID: "okchain", Domain: "evil.com"}, challenge, sig); err == nil {
+		t.Fatal("the signature is valid for another domain")
+	}
+	if err := tx.VerifyArbitraryByAddress(accAddr, tx.OffChainDomain{ChainID: "testnet", Domain: "example.com"}, challenge, sig); err == nil {
+		t.Fatal("the signature is valid for another chain")
+	}
+	otherAddr, _ := types.AccAddressFromBech32(addr1)
+	if err := tx.VerifyArbitraryByAddress(otherAddr, domain, challenge, sig); err == nil {
+		t.Fatal("the signature is valid for another address")
+	}
+	if _, err := tx.SignArbitrary(kb, "alice", passWd, tx.OffChainDomain{ChainID: "okchain"}, challenge); err == nil {
+		t.Fatal("data is signed without a domain")
+	}
+
+	// the sign doc is shaped as the one of a tx for the Ledger devices
+	doc := string(tx.OffChainSignBytes(domain, accAddr, challenge))
+	expected := `{"account_number":"0","chain_id":"okchain","fee":{"amount":[],"gas":"0"},"memo":"","msgs":[{"type":"okchain/MsgSignData","value":{"data":"bG9naW4gY2hhbGxlbmdlIDQy","domain":"example.com","signer":"` + addr + `"}}],"sequence":"0"}`
+	if doc != expected {
+		t.Fatalf("unexpected sign doc:\n%s\n%s", doc, expected)
+	}
+	if strings.ContainsAny(doc, " \n") {
+		t.Fatal("the sign doc isn't compact")
+	}
+}
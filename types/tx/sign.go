@@ -24,6 +24,24 @@ func StdSignBytes(chainID string, accnum uint64, sequence uint64, fee StdFee, ms
 	for _, msg := range msgs {
 		msgsBytes = append(msgsBytes, json.RawMessage(msg.GetSignBytes()))
 	}
+	return stdSignBytes(chainID, accnum, sequence, fee, msgsBytes, memo)
+}
+
+// StdSignBytesWithNetwork returns the bytes to sign for a tx on the network, whose chain-id is the
+// one of the network and whose msgs encode the addresses by the prefixes of the network
+func StdSignBytesWithNetwork(network types.Network, accnum uint64, sequence uint64, fee StdFee, msgs []types.Msg, memo string) []byte {
+	var msgsBytes []json.RawMessage
+	for _, msg := range msgs {
+		bz, err := network.FromDefaultJSON(msg.GetSignBytes())
+		if err != nil {
+			panic(err)
+		}
+		msgsBytes = append(msgsBytes, json.RawMessage(bz))
+	}
+	return stdSignBytes(network.ChainID, accnum, sequence, fee, msgsBytes, memo)
+}
+
+func stdSignBytes(chainID string, accnum uint64, sequence uint64, fee StdFee, msgsBytes []json.RawMessage, memo string) []byte {
 	bz, err := MsgCdc.MarshalJSON(StdSignDoc{
 		AccountNumber: accnum,
 		ChainID:       chainID,
@@ -38,9 +56,10 @@ func StdSignBytes(chainID string, accnum uint64, sequence uint64, fee StdFee, ms
 	return types.MustSortJSON(bz)
 }
 
-func makeSignature(keybase keys.Keybase, name, passphrase string,
+func makeSignature(keybase keys.Keybase, name, passphrase string, network types.Network,
 	msg StdSignMsg) (sig StdSignature, err error) {
-	sigBytes, pubkey, err := keybase.Sign(name, passphrase, msg.Bytes())
+	signBytes := StdSignBytesWithNetwork(network, msg.AccountNumber, msg.Sequence, msg.Fee, msg.Msgs, msg.Memo)
+	sigBytes, pubkey, err := keybase.Sign(name, passphrase, signBytes)
 	if err != nil {
 		return
 	}
@@ -3,10 +3,15 @@ package utils
 import (
 	"encoding/hex"
 	"fmt"
+
 	"github.com/okex/okchain-go-sdk/common/libs/pkg/errors"
+	"github.com/okex/okchain-go-sdk/common/log"
 	"github.com/okex/okchain-go-sdk/crypto/go-bip39"
 	"github.com/okex/okchain-go-sdk/crypto/keys"
+	"github.com/okex/okchain-go-sdk/crypto/keys/hd"
 	"github.com/okex/okchain-go-sdk/crypto/keys/mintkey"
+	"github.com/okex/okchain-go-sdk/types"
+	"github.com/tendermint/tendermint/crypto"
 	"github.com/tendermint/tendermint/crypto/secp256k1"
 )
 
@@ -17,66 +22,88 @@ const (
 )
 
 var (
-	Kb keys.Keybase
+	Kb     keys.Keybase
+	logger = log.NewNopLogger()
 )
 
 func init() {
 	Kb = keys.NewInMemory()
 }
 
-func CreateAccount(name, passWd string) (keys.Info, string, error) {
-	if len(name) == 0 {
-		name = "OKer"
-		fmt.Println("Default name : \"OKer\"")
+// SetLogger sets the logger used by the account functions. Nothing is logged by default.
+func SetLogger(l log.Logger) {
+	if l == nil {
+		l = log.NewNopLogger()
 	}
+	logger = l.With("module", "utils")
+}
 
-	if len(passWd) == 0 {
-		passWd = "12345678"
-		fmt.Println("Default passWd : \"12345678\"")
-	}
+// AccountParams are the optional parameters of the account creation. The zero value creates the
+// accounts as CreateAccount and CreateAccountWithMnemo do.
+type AccountParams struct {
+	// MnemonicWords is the number of words of a new mnemonic: 12, 15, 18, 21 or 24. It's 12 if it's 0.
+	MnemonicWords int
+	// BIP39Passphrase is the optional passphrase of the BIP39 seed, known as the 25th word. The same
+	// mnemonic with another passphrase derives other keys.
+	BIP39Passphrase string
+	// HDPath is the BIP44 path of the key, e.g. hd.NewFundraiserParams(account, index). It's
+	// 44'/996'/0'/0/0 if it's nil.
+	HDPath *hd.BIP44Params
+	// Language is the language of the wordlist of a new mnemonic, like bip39.Japanese. It's english
+	// if it's empty. The language of a given mnemonic is detected.
+	Language string
+}
 
-	var entropySeed []byte
-	entropySeed, err := bip39.NewEntropy(mnemonicEntropySize)
-	if err != nil {
-		return nil, "", fmt.Errorf("bip39.NewEntropy err : %s", err.Error())
+func (params AccountParams) hdPath() hd.BIP44Params {
+	if params.HDPath == nil {
+		return *hd.NewFundraiserParams(0, 0)
 	}
+	return *params.HDPath
+}
 
-	mnemo, err := bip39.NewMnemonic(entropySeed[:])
-	if err != nil {
-		return nil, "", fmt.Errorf("bip39.NewMnemonic err : %s", err.Error())
-	}
+func CreateAccount(name, passWd string) (keys.Info, string, error) {
+	return CreateAccountWithParams(name, passWd, AccountParams{})
+}
 
-	info, err := Kb.CreateAccount(name, mnemo, "", passWd, 0, 0)
+// CreateAccountWithParams creates an account from a new mnemonic of the given number of words,
+// deriving the key by the BIP39 passphrase and the HD path of the params
+func CreateAccountWithParams(name, passWd string, params AccountParams) (keys.Info, string, error) {
+	mnemo, err := GenerateMnemonicInLanguage(params.MnemonicWords, params.Language)
 	if err != nil {
-		return nil, "", fmt.Errorf("Kb.CreateAccount err : %s", err.Error())
+		return nil, "", err
 	}
-
-	return info, mnemo, nil
-
+	return CreateAccountWithMnemoAndParams(mnemo, name, passWd, params)
 }
 
 func CreateAccountWithMnemo(mnemo, name, passWd string) (keys.Info, string, error) {
+	return CreateAccountWithMnemoAndParams(mnemo, name, passWd, AccountParams{})
+}
+
+// CreateAccountWithMnemoAndParams recovers an account from the mnemonic, deriving the key by the
+// BIP39 passphrase and the HD path of the params. The number of words and the language of the params
+// are ignored.
+func CreateAccountWithMnemoAndParams(mnemo, name, passWd string, params AccountParams) (keys.Info, string, error) {
 	if len(mnemo) == 0 {
 		return nil, "", errors.New("err : no mnemo input")
 	}
 
 	if len(name) == 0 {
 		name = "OKer"
-		fmt.Println("Default name : \"OKer\"")
+		logger.Info("no name input, the default one is used", "name", name)
 	}
 
 	if len(passWd) == 0 {
 		passWd = "12345678"
-		fmt.Println("Default passWd : \"12345678\"")
+		logger.Warn("no password input, the default one is used")
 	}
 
 	if !bip39.IsMnemonicValid(mnemo) {
 		return nil, "", errors.New("err : mnemonic is not valid")
 	}
 
-	info, err := Kb.CreateAccount(name, mnemo, "", passWd, 0, 0)
+	info, err := Kb.Derive(name, mnemo, params.BIP39Passphrase, passWd, params.hdPath())
 	if err != nil {
-		return nil, "", fmt.Errorf("Kb.CreateAccount err : %s", err.Error())
+		return nil, "", fmt.Errorf("Kb.Derive err : %s", err.Error())
 	}
 
 	return info, mnemo, nil
@@ -101,15 +128,87 @@ func CreateAccountWithPrivateKey(privateKey, name, passWd string) (keys.Info, er
 }
 
 func GenerateMnemonic() (string, error) {
+	return GenerateMnemonicWithWords(0)
+}
+
+// GenerateMnemonicWithWords generates a mnemonic of 12, 15, 18, 21 or 24 words, which carry 128 to
+// 256 bits of entropy. It's 12 words if words is 0.
+func GenerateMnemonicWithWords(words int) (string, error) {
+	return GenerateMnemonicInLanguage(words, "")
+}
+
+// GenerateMnemonicInLanguage generates a mnemonic of the number of words in the wordlist of the
+// language, like bip39.ChineseSimplified. It's english if the language is empty.
+func GenerateMnemonicInLanguage(words int, language string) (string, error) {
+	if language == "" {
+		language = bip39.English
+	}
+	entropySize := mnemonicEntropySize
+	if words != 0 {
+		if words < 12 || words > 24 || words%3 != 0 {
+			return "", fmt.Errorf("a mnemonic has 12, 15, 18, 21 or 24 words, got %d", words)
+		}
+		// every 3 words carry 32 bits of entropy and a bit of checksum
+		entropySize = words / 3 * 32
+	}
+
 	var entropySeed []byte
-	entropySeed, err := bip39.NewEntropy(mnemonicEntropySize)
+	entropySeed, err := bip39.NewEntropy(entropySize)
 	if err != nil {
 		return "", fmt.Errorf("bip39.NewEntropy err : %s", err.Error())
 	}
 
-	mnemo, err := bip39.NewMnemonic(entropySeed[:])
+	mnemo, err := bip39.NewMnemonicInLanguage(entropySeed[:], language)
 	if err != nil {
-		return "", fmt.Errorf("bip39.NewMnemonic err : %s", err.Error())
+		return "", fmt.Errorf("bip39.NewMnemonicInLanguage err : %s", err.Error())
 	}
 	return mnemo, nil
 }
+
+// GenerateExtendedKeysFromMnemo returns the extended private and public keys, xprv... and
+// xpub..., of a BIP32 path like the account 44'/996'/0'. The xpub derives the public keys of the
+// addresses under the path without the private key.
+func GenerateExtendedKeysFromMnemo(mnemo, bip39Passphrase, hdPath string) (xprv, xpub string, err error) {
+	seed, err := bip39.NewSeedWithErrorChecking(mnemo, bip39Passphrase)
+	if err != nil {
+		return "", "", err
+	}
+	key, err := hd.NewMasterKey(seed).DerivePath(hdPath)
+	if err != nil {
+		return "", "", err
+	}
+	return key.String(), key.Neuter().String(), nil
+}
+
+// DerivePubKeyFromXPub derives the public key and the address of a non-hardened path like 0/5
+// under the extended key, which is the 6th receiving address if it's the xpub of an account
+func DerivePubKeyFromXPub(xpub, hdPath string) (crypto.PubKey, types.AccAddress, error) {
+	key, err := hd.NewExtendedKeyFromString(xpub)
+	if err != nil {
+		return nil, nil, err
+	}
+	if key, err = key.Neuter().DerivePath(hdPath); err != nil {
+		return nil, nil, err
+	}
+	var pubKey secp256k1.PubKeySecp256k1
+	copy(pubKey[:], key.PubKeyBytes())
+	return pubKey, types.AccAddress(pubKey.Address()), nil
+}
+
+// CreateWatchOnlyAccount derives the public key of the path under the xpub and stores it in Kb as
+// an offline key, which can't sign
+func CreateWatchOnlyAccount(xpub, hdPath, name string) (keys.Info, error) {
+	if len(name) == 0 {
+		return nil, errors.New("err : no name input")
+	}
+	pubKey, _, err := DerivePubKeyFromXPub(xpub, hdPath)
+	if err != nil {
+		return nil, err
+	}
+	return Kb.CreateOffline(name, pubKey)
+}
+
+// DecryptWithKey decrypts the data encrypted to the public key of the named key of Kb
+func DecryptWithKey(name, passWd string, ciphertext []byte) ([]byte, error) {
+	return Kb.Decrypt(name, passWd, ciphertext)
+}
@@ -1,8 +1,15 @@
 package utils
 
 import (
+	"encoding/hex"
 	"fmt"
+	"strings"
 	"testing"
+
+	"github.com/okex/okchain-go-sdk/crypto/go-bip39"
+	"github.com/okex/okchain-go-sdk/crypto/keys"
+	"github.com/okex/okchain-go-sdk/crypto/keys/hd"
+	"golang.org/x/text/unicode/norm"
 )
 
 const (
@@ -58,6 +65,89 @@ func TestGeneratePrivateKeyFromMnemo(t *testing.T) {
 	fmt.Println(privateKey)
 }
 
+func TestGenerateMnemonicWithWords(t *testing.T) {
+	mnemo, err := GenerateMnemonicWithWords(24)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(strings.Fields(mnemo)) != 24 {
+		t.Fatalf("unexpected mnemonic: %s", mnemo)
+	}
+	if _, err := GenerateMnemonicWithWords(13); err == nil {
+		t.Fatal("a mnemonic of 13 words is generated")
+	}
+}
+
+func TestCreateAccountWithParams(t *testing.T) {
+	info, mnemo, err := CreateAccountWithParams("bob", passWd, AccountParams{MnemonicWords: 24})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(strings.Fields(mnemo)) != 24 || info.GetName() != "bob" {
+		t.Fatalf("unexpected account: %s %s", info.GetName(), mnemo)
+	}
+
+	defaultInfo, _, err := CreateAccountWithMnemo(mnemonic, "default", passWd)
+	if err != nil {
+		t.Fatal(err)
+	}
+	passphraseInfo, _, err := CreateAccountWithMnemoAndParams(mnemonic, "passphrase", passWd, AccountParams{BIP39Passphrase: "25th word"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if passphraseInfo.GetAddress().Equals(defaultInfo.GetAddress()) {
+		t.Fatal("the bip39 passphrase doesn't change the key")
+	}
+
+	// the key of the index 1 is the one generated by the path
+	indexInfo, _, err := CreateAccountWithMnemoAndParams(mnemonic, "index", passWd, AccountParams{HDPath: hd.NewFundraiserParams(0, 1)})
+	if err != nil {
+		t.Fatal(err)
+	}
+	privateKey, err := GeneratePrivateKeyFromMnemoWithPath(mnemonic, "", "m/44'/996'/0'/0/1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	pathInfo, err := CreateAccountWithPrivateKey(privateKey, "path", passWd)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !indexInfo.GetAddress().Equals(pathInfo.GetAddress()) || indexInfo.GetAddress().Equals(defaultInfo.GetAddress()) {
+		t.Fatalf("unexpected address of the index 1: %s %s", indexInfo.GetAddress(), pathInfo.GetAddress())
+	}
+	if _, err := GeneratePrivateKeyFromMnemoWithPath(mnemonic, "", "44'//0"); err == nil {
+		t.Fatal("an invalid path is accepted")
+	}
+}
+
+func TestCreateWatchOnlyAccount(t *testing.T) {
+	_, xpub, err := GenerateExtendedKeysFromMnemo(mnemonic, "", "m/44'/996'/0'")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// the address 0/1 under the account xpub is the one of the path 44'/996'/0'/0/1
+	info, err := CreateWatchOnlyAccount(xpub, "0/1", "watch")
+	if err != nil {
+		t.Fatal(err)
+	}
+	privateKey, err := GeneratePrivateKeyFromMnemoWithPath(mnemonic, "", "44'/996'/0'/0/1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	pathInfo, err := CreateAccountWithPrivateKey(privateKey, "path", passWd)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if info.GetType() != keys.TypeOffline || !info.GetAddress().Equals(pathInfo.GetAddress()) {
+		t.Fatalf("unexpected watch-only key: %s %s", info.GetType(), info.GetAddress())
+	}
+
+	if _, _, err := DerivePubKeyFromXPub(xpub, "0'/1"); err == nil {
+		t.Fatal("a hardened key is derived from the xpub")
+	}
+}
+
 func assertNotEqual(t *testing.T, a, b interface{}) {
 	if a != b {
 		t.Errorf("test failed: %s", a)
@@ -69,3 +159,157 @@ func assertEqual(t *testing.T, a, b interface{}) {
 		t.Errorf("test failed: %s", a)
 	}
 }
+
+// the official BIP39 test vectors: the english ones of the spec with the passphrase TREZOR, and
+// the japanese ones, whose words are separated by ideographic spaces and whose passphrase only
+// derives the seed once normalized in NFKD
+var bip39Vectors = []struct {
+	language, entropy, mnemonic, passphrase, seed string
+}{
+	{
+		language:   bip39.English,
+		entropy:    "00000000000000000000000000000000",
+		mnemonic:   "abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon about",
+		passphrase: "TREZOR",
+		seed:       "c55257c360c07c72029aebc1b53c05ed0362ada38ead3e3e9efa3708e53495531f09a6987599d18264c1e1c92f2cf141630c7a3c4ab7c81b2f001698e7463b04",
+	},
+	{
+		language:   bip39.English,
+		entropy:    "7f7f7f7f7f7f7f7f7f7f7f7f7f7f7f7f",
+		mnemonic:   "legal winner thank year wave sausage worth useful legal winner thank yellow",
+		passphrase: "TREZOR",
+		seed:       "2e8905819b8723fe2c1d161860e5ee1830318dbf49a83bd451cfb8440c28bd6fa457fe1296106559a3c80937a1c1069be3a3a5bd381ee6260e8d9739fce1f607",
+	},
+	{
+		language:   bip39.English,
+		entropy:    "ffffffffffffffffffffffffffffffff",
+		mnemonic:   "zoo zoo zoo zoo zoo zoo zoo zoo zoo zoo zoo wrong",
+		passphrase: "TREZOR",
+		seed:       "ac27495480225222079d7be181583751e86f571027b0497b5b5d11218e0a8a13332572917f0f8e5a589620c6f15b11c61dee327651a14c34e18231052e48c069",
+	},
+	{
+		language:   bip39.Japanese,
+		entropy:    "00000000000000000000000000000000",
+		mnemonic:   "あいこくしん　あいこくしん　あいこくしん　あいこくしん　あいこくしん　あいこくしん　あいこくしん　あいこくしん　あいこくしん　あいこくしん　あいこくしん　あおぞら",
+		passphrase: "㍍ガバヴァぱばぐゞちぢ十人十色",
+		seed:       "a262d6fb6122ecf45be09c50492b31f92e9beb7d9a845987a02cefda57a15f9c467a17872029a9e92299b5cbdf306e3a0ee620245cbd508959b6cb7ca637bd55",
+	},
+	{
+		language:   bip39.Japanese,
+		entropy:    "7f7f7f7f7f7f7f7f7f7f7f7f7f7f7f7f",
+		mnemonic:   "そつう　れきだい　ほんやく　わかす　りくつ　ばいか　ろせん　やちん　そつう　れきだい　ほんやく　わかめ",
+		passphrase: "㍍ガバヴァぱばぐゞちぢ十人十色",
+		seed:       "aee025cbe6ca256862f889e48110a6a382365142f7d16f2b9545285b3af64e542143a577e9c144e101a6bdca18f8d97ec3366ebf5b088b1c1af9bc31346e60d9",
+	},
+	{
+		language:   bip39.Japanese,
+		entropy:    "80808080808080808080808080808080",
+		mnemonic:   "そとづら　あまど　おおう　あこがれる　いくぶん　けいけん　あたえる　いよく　そとづら　あまど　おおう　あかちゃん",
+		passphrase: "㍍ガバヴァぱばぐゞちぢ十人十色",
+		seed:       "e51736736ebdf77eda23fa17e31475fa1d9509c78f1deb6b4aacfbd760a7e2ad769c714352c95143b5c1241985bcb407df36d64e75dd5a2b78ca5d2ba82a3544",
+	},
+	{
+		language:   bip39.Japanese,
+		entropy:    "ffffffffffffffffffffffffffffffff",
+		mnemonic:   "われる　われる　われる　われる　われる　われる　われる　われる　われる　われる　われる　ろんぶん",
+		passphrase: "㍍ガバヴァぱばぐゞちぢ十人十色",
+		seed:       "4cd2ef49b479af5e1efbbd1e0bdc117f6a29b1010211df4f78e2ed40082865793e57949236c43b9fe591ec70e5bb4298b8b71dc4b267bb96ed4ed282c8f7761c",
+	},
+}
+
+func TestBIP39Vectors(t *testing.T) {
+	for _, vector := range bip39Vectors {
+		entropy, err := hex.DecodeString(vector.entropy)
+		if err != nil {
+			t.Fatal(err)
+		}
+		// the words of the wordlists are normalized in NFKD, unlike the ones of the vectors
+		mnemo, err := bip39.NewMnemonicInLanguage(entropy, vector.language)
+		if err != nil {
+			t.Fatal(err)
+		}
+		separator := " "
+		if vector.language == bip39.Japanese {
+			separator = "\u3000"
+		}
+		words := strings.Split(vector.mnemonic, separator)
+		for i := range words {
+			words[i] = norm.NFKD.String(words[i])
+		}
+		if mnemo != strings.Join(words, separator) {
+			t.Fatalf("unexpected mnemonic of %s: %s", vector.entropy, mnemo)
+		}
+		if language, err := bip39.DetectLanguage(vector.mnemonic); err != nil || language != vector.language {
+			t.Fatalf("unexpected language of %s: %s %v", vector.mnemonic, language, err)
+		}
+		seed, err := bip39.NewSeedWithErrorChecking(vector.mnemonic, vector.passphrase)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if hex.EncodeToString(seed) != vector.seed {
+			t.Fatalf("unexpected seed of %s: %x", vector.mnemonic, seed)
+		}
+	}
+}
+
+func TestCreateAccountInLanguage(t *testing.T) {
+	info, mnemo, err := CreateAccountWithParams("japanese", passWd, AccountParams{Language: bip39.Japanese})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if language, err := bip39.DetectLanguage(mnemo); err != nil || language != bip39.Japanese {
+		t.Fatalf("unexpected language of %s: %s %v", mnemo, language, err)
+	}
+	if len(strings.Split(mnemo, "\u3000")) != 12 {
+		t.Fatalf("the japanese mnemonic isn't separated by ideographic spaces: %s", mnemo)
+	}
+	restored, _, err := CreateAccountWithMnemo(strings.Replace(mnemo, "\u3000", " ", -1), "restored", passWd)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !restored.GetAddress().Equals(info.GetAddress()) {
+		t.Fatalf("unexpected address restored: %s %s", restored.GetAddress(), info.GetAddress())
+	}
+
+	// the japanese vector typed in composed characters with its passphrase derives the same key
+	// as the one normalized in NFKD and separated by single spaces
+	vector := bip39Vectors[4]
+	composed, _, err := CreateAccountWithMnemoAndParams(vector.mnemonic, "composed", passWd, AccountParams{BIP39Passphrase: vector.passphrase})
+	if err != nil {
+		t.Fatal(err)
+	}
+	decomposed, _, err := CreateAccountWithMnemoAndParams(bip39.NormalizeMnemonic(vector.mnemonic), "decomposed", passWd, AccountParams{BIP39Passphrase: norm.NFKD.String(vector.passphrase)})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !composed.GetAddress().Equals(decomposed.GetAddress()) {
+		t.Fatal("the mnemonic or the passphrase isn't normalized")
+	}
+
+	// and so does a spanish mnemonic with its accents composed
+	spanish, err := bip39.NewMnemonicInLanguage(make([]byte, 16), bip39.Spanish)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(spanish, "a\u0301baco") {
+		t.Fatalf("the spanish mnemonic isn't normalized: %s", spanish)
+	}
+	composed, _, err = CreateAccountWithMnemo(norm.NFC.String(spanish), "composed", passWd)
+	if err != nil {
+		t.Fatal(err)
+	}
+	decomposed, _, err = CreateAccountWithMnemo(spanish, "decomposed", passWd)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !composed.GetAddress().Equals(decomposed.GetAddress()) {
+		t.Fatal("the spanish mnemonic isn't normalized")
+	}
+
+	if _, _, err := Kb.CreateMnemonic("korean", keys.Korean, passWd, keys.Secp256k1); err != nil {
+		t.Fatal(err)
+	}
+	if _, _, err := Kb.CreateMnemonic("unknown", keys.Language(0), passWd, keys.Secp256k1); err != keys.ErrUnsupportedLanguage {
+		t.Fatalf("expected an unsupported language but got %v", err)
+	}
+}
@@ -2,7 +2,6 @@ package utils
 
 import (
 	"encoding/json"
-	"errors"
 	"fmt"
 	"github.com/okex/okchain-go-sdk/types"
 	"regexp"
@@ -10,13 +9,19 @@ import (
 )
 
 var (
-	reDecAmt    = `[[:digit:]]*\.?[[:digit:]]+`
-	reSpc       = `[[:space:]]*`
-	reDnmString = `[a-z][a-z0-9]{0,5}(\-[a-z0-9]{3})?`
-	reDecCoin   = regexp.MustCompile(fmt.Sprintf(`^(%s)%s(%s)$`, reDecAmt, reSpc, reDnmString))
-	ReDnm       = regexp.MustCompile(fmt.Sprintf(`^%s$`, reDnmString))
+	reDecAmt = `[[:digit:]]*\.?[[:digit:]]+`
+	reSpc    = `[[:space:]]*`
+	// ReDnm matches the default denoms of OKChain. The configured ones are checked by
+	// types.ValidateDenom.
+	ReDnm = regexp.MustCompile(fmt.Sprintf(`^%s$`, types.DefaultCoinDenomRegex))
 )
 
+// decCoinRegex returns the regex of a decimal coin, whose denom matches the regex set by
+// types.SetCoinDenomRegex
+func decCoinRegex() *regexp.Regexp {
+	return regexp.MustCompile(fmt.Sprintf(`^(%s)%s(%s)$`, reDecAmt, reSpc, types.CoinDenomRegex()))
+}
+
 func ParseCoins(coinsStr string) (coins types.Coins, err error) {
 	coinsStr = strings.TrimSpace(coinsStr)
 	if len(coinsStr) == 0 {
@@ -43,28 +48,95 @@ func ParseCoins(coinsStr string) (coins types.Coins, err error) {
 	return coins, nil
 }
 
+// ParseCoin parses a coin like 10.24okt, whose amount is stored in the smallest unit of the chain,
+// i.e. 1024000000. An amount with more than types.Precision decimal places is rejected.
 func ParseCoin(coinStr string) (coin types.Coin, err error) {
+	decCoin, err := ParseDecCoin(coinStr)
+	if err != nil {
+		return types.Coin{}, err
+	}
+
+	// the smallest unit is the last decimal place of a DecCoin, so nothing is truncated
+	coin, _ = decCoin.TruncateDecimal(types.Precision)
+	return coin, nil
+}
+
+// ParseDecCoin parses a decimal coin like 10.24okt. An amount with more than types.Precision
+// decimal places is rejected.
+func ParseDecCoin(coinStr string) (types.DecCoin, error) {
+	return ParseDecCoinWithPrecision(coinStr, types.Precision)
+}
+
+// ParseDecCoinWithPrecision parses a decimal coin whose amount has at most prec decimal places,
+// e.g. the quantity digits of a product
+func ParseDecCoinWithPrecision(coinStr string, prec int64) (types.DecCoin, error) {
+	if prec < 0 || prec > types.Precision {
+		return types.DecCoin{}, fmt.Errorf("invalid precision %d, which has to be between 0 and %d", prec, types.Precision)
+	}
 	coinStr = strings.TrimSpace(coinStr)
 
-	matches := reDecCoin.FindStringSubmatch(coinStr)
+	matches := decCoinRegex().FindStringSubmatch(coinStr)
 	if matches == nil {
-		return types.Coin{}, fmt.Errorf("invalid coin expression: %s", coinStr)
+		return types.DecCoin{}, fmt.Errorf("invalid coin expression: %s", coinStr)
 	}
 
 	denomStr, amountStr := matches[2], matches[1]
 
+	if i := strings.Index(amountStr, "."); i != -1 && int64(len(amountStr)-i-1) > prec {
+		return types.DecCoin{}, fmt.Errorf("amount %s of %s has more than %d decimal places", amountStr, coinStr, prec)
+	}
 	amount, err := types.NewDecFromStr(amountStr)
 	if err != nil {
-		return types.Coin{}, fmt.Errorf("failed to parse coin amount %s: %s", amountStr, err.Error())
+		return types.DecCoin{}, fmt.Errorf("failed to parse coin amount %s: %s", amountStr, err.Error())
 	}
 
 	if err := validateDenom(denomStr); err != nil {
-		return types.Coin{}, fmt.Errorf("invalid denom cannot contain upper case characters or spaces: %s", err)
+		return types.DecCoin{}, fmt.Errorf("invalid denom cannot contain upper case characters or spaces: %s", err)
 	}
 
-	coin = types.NewCoin(denomStr, types.NewIntFromBigInt(amount.Int))
+	return types.NewDecCoinFromDec(denomStr, amount), nil
+}
 
-	return coin, nil
+// ParseDecCoins parses decimal coins separated by commas like 1.5okt,10xxb-127. The coins are
+// sorted and have to be positive with distinct denoms.
+func ParseDecCoins(coinsStr string) (types.DecCoins, error) {
+	coinsStr = strings.TrimSpace(coinsStr)
+	if len(coinsStr) == 0 {
+		return nil, nil
+	}
+
+	var coins types.DecCoins
+	for _, coinStr := range strings.Split(coinsStr, ",") {
+		coin, err := ParseDecCoin(coinStr)
+		if err != nil {
+			return nil, err
+		}
+		coins = append(coins, coin)
+	}
+
+	// Sort coins for determinism.
+	coins.Sort()
+
+	if !coins.IsValid() {
+		return nil, fmt.Errorf("parseDecCoins invalid: %s", coins)
+	}
+	return coins, nil
+}
+
+// FormatDecCoin formats a decimal coin with prec decimal places, e.g. 10.24okt with 2. The amount
+// isn't rounded, so an error is returned if it has more decimal places.
+func FormatDecCoin(coin types.DecCoin, prec int64) (string, error) {
+	if prec < 0 || prec > types.Precision {
+		return "", fmt.Errorf("invalid precision %d, which has to be between 0 and %d", prec, types.Precision)
+	}
+
+	// the amount is formatted with types.Precision decimal places
+	amount := coin.Amount.String()
+	cut := len(amount) - int(types.Precision-prec)
+	if strings.Trim(amount[cut:], "0") != "" {
+		return "", fmt.Errorf("amount of %s has more than %d decimal places", coin, prec)
+	}
+	return strings.TrimSuffix(amount[:cut], ".") + coin.Denom, nil
 }
 
 func StrToTransfers(str string) (transfers []types.TransferUnit, err error) {
@@ -94,8 +166,5 @@ func AmountToCoins(amount string) types.Coins {
 }
 
 func validateDenom(denom string) error {
-	if !ReDnm.MatchString(denom) {
-		return errors.New("illegal characters")
-	}
-	return nil
+	return types.ValidateDenom(denom)
 }
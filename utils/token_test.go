@@ -0,0 +1,88 @@
+package utils
+
+import (
+	"testing"
+
+	"github.com/okex/okchain-go-sdk/types"
+)
+
+func TestParseDecCoin(t *testing.T) {
+	// the amount of a coin is stored in the smallest unit
+	coin, err := ParseCoin("10.24okt")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if coin.Amount.Int64() != 1024000000 || coin.String() != "10.24000000okt" {
+		t.Fatalf("unexpected coin parsed: %s", coin.Amount)
+	}
+
+	decCoin, err := ParseDecCoin(" 10.24 xxb-127")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if decCoin.Denom != "xxb-127" || !decCoin.Amount.Equal(types.MustNewDecFromStr("10.24")) {
+		t.Fatalf("unexpected decimal coin parsed: %s", decCoin)
+	}
+	for _, prec := range []int64{2, 4, types.Precision} {
+		formatted, err := FormatDecCoin(decCoin, prec)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if parsed, err := ParseDecCoinWithPrecision(formatted, prec); err != nil || !parsed.IsEqual(decCoin) {
+			t.Fatalf("failed to parse %s back: %v", formatted, err)
+		}
+	}
+	if formatted, _ := FormatDecCoin(decCoin, 4); formatted != "10.2400xxb-127" {
+		t.Fatalf("unexpected coin formatted: %s", formatted)
+	}
+	if formatted, _ := FormatDecCoin(types.NewDecCoinFromDec("okt", types.NewDec(10)), 0); formatted != "10okt" {
+		t.Fatalf("unexpected coin formatted: %s", formatted)
+	}
+	if _, err := FormatDecCoin(decCoin, 1); err == nil {
+		t.Fatal("the amount is rounded to fewer decimal places")
+	}
+
+	// the amounts beyond the precision are rejected instead of truncated
+	if _, err := ParseCoin("0.000000001okt"); err == nil {
+		t.Fatal("an amount beyond the precision of the chain is parsed")
+	}
+	if _, err := ParseDecCoinWithPrecision("1.23456okt", 4); err == nil {
+		t.Fatal("an amount beyond the given precision is parsed")
+	}
+	if _, err := ParseDecCoin("1OKT"); err == nil {
+		t.Fatal("an invalid coin is parsed")
+	}
+
+	coins, err := ParseDecCoins("2xxb-127,1.5okt")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if coins.String() != "1.50000000okt,2.00000000xxb-127" {
+		t.Fatalf("unexpected coins parsed: %s", coins)
+	}
+	if _, err := ParseDecCoins("1okt,2okt"); err == nil {
+		t.Fatal("coins of the same denom are parsed")
+	}
+}
+
+func TestSetCoinDenomRegex(t *testing.T) {
+	if _, err := ParseCoin("1okchaintoken"); err == nil {
+		t.Fatal("a symbol longer than the default denoms is parsed")
+	}
+	if err := types.SetCoinDenomRegex("[a-z"); err == nil {
+		t.Fatal("an invalid regex is set")
+	}
+
+	if err := types.SetCoinDenomRegex(`[a-z][a-z0-9]{2,15}(/[a-z0-9]{3,8})?`); err != nil {
+		t.Fatal(err)
+	}
+	defer types.SetCoinDenomRegex(types.DefaultCoinDenomRegex)
+	for _, coinStr := range []string{"1okchaintoken", "1.5usdk/erc20"} {
+		if _, err := ParseCoin(coinStr); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if _, err := ParseCoin("1xxb-127"); err == nil {
+		t.Fatal("a denom of the replaced regex is parsed")
+	}
+}
@@ -2,6 +2,8 @@ package utils
 
 import (
 	"encoding/hex"
+	"strings"
+
 	"github.com/okex/okchain-go-sdk/common/libs/pkg/errors"
 	"github.com/okex/okchain-go-sdk/crypto/go-bip39"
 	"github.com/okex/okchain-go-sdk/crypto/keys/hd"
@@ -17,13 +19,28 @@ func AddressStoreKey(addr types.AccAddress) []byte {
 }
 
 func GeneratePrivateKeyFromMnemo(mnemo string) (string, error) {
-	hdPath := hd.NewFundraiserParams(0, 0)
-	seed, err := bip39.NewSeedWithErrorChecking(mnemo, "")
+	return GeneratePrivateKeyFromMnemoWithPath(mnemo, "", hd.FullFundraiserPath)
+}
+
+// GeneratePrivateKeyFromMnemoWithParams generates the hex private key of the BIP44 path from the
+// mnemonic and the BIP39 passphrase
+func GeneratePrivateKeyFromMnemoWithParams(mnemo, bip39Passphrase string, params hd.BIP44Params) (string, error) {
+	return GeneratePrivateKeyFromMnemoWithPath(mnemo, bip39Passphrase, params.String())
+}
+
+// GeneratePrivateKeyFromMnemoWithPath generates the hex private key of a BIP32 path like
+// 44'/996'/0'/0/1 or m/44'/996'/1'/0/0 from the mnemonic and the BIP39 passphrase
+func GeneratePrivateKeyFromMnemoWithPath(mnemo, bip39Passphrase, hdPath string) (string, error) {
+	hdPath = strings.TrimPrefix(hdPath, "m/")
+	seed, err := bip39.NewSeedWithErrorChecking(mnemo, bip39Passphrase)
 	if err != nil {
 		return "", err
 	}
 	masterPrivateKey, ch := hd.ComputeMastersFromSeed(seed)
-	derivedPrivateKey, err := hd.DerivePrivateKeyForPath(masterPrivateKey, ch, hdPath.String())
+	derivedPrivateKey, err := hd.DerivePrivateKeyForPath(masterPrivateKey, ch, hdPath)
+	if err != nil {
+		return "", err
+	}
 	return hex.EncodeToString(derivedPrivateKey[:]), nil
 }
 